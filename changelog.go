@@ -23,6 +23,8 @@ and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0
 `
 
 const (
+	dateFormat    = "2006-01-02"
+	yankedSuffix  = "[yanked]"
 	releasePrefix = "## "
 	changePrefix  = "### "
 	linePrefix    = "- "
@@ -45,6 +47,9 @@ type changelogParser struct {
 	currentText            string
 	prefix                 string
 
+	lastEntries  *[]string
+	lastEntryEnd int
+
 	currentChangeType changeType
 }
 
@@ -77,7 +82,7 @@ func (p *changelogParser) resetLoop() {
 
 func (p *changelogParser) getDeformattedText() string {
 	if p.currentDeformattedText == "" {
-		p.currentDeformattedText = strings.ReplaceAll(strings.TrimSpace(strings.ToLower(string(p.CurrentNode.Text(p.Changelog)))), " ", "")
+		p.currentDeformattedText = strings.ReplaceAll(strings.TrimSpace(strings.ToLower(*p.getCurrentText())), " ", "")
 	}
 
 	return p.currentDeformattedText
@@ -85,7 +90,7 @@ func (p *changelogParser) getDeformattedText() string {
 
 func (p *changelogParser) getCurrentText() *string {
 	if p.currentText == "" {
-		p.currentText = string(p.CurrentNode.Text(p.Changelog))
+		p.currentText = nodeText(p.CurrentNode, p.Changelog)
 	}
 
 	return &p.currentText
@@ -103,6 +108,7 @@ func (p *changelogParser) checkUnreleased() error {
 			VersionText: &versionText,
 		}
 		p.currentChange = p.Unreleased
+		p.startSpan()
 	}
 
 	return nil
@@ -124,6 +130,7 @@ func (p *changelogParser) checkUnreleasedIncrement() error {
 			VersionText: &versionText,
 		}
 		p.currentChange = p.Unreleased
+		p.startSpan()
 	}
 
 	return nil
@@ -147,10 +154,13 @@ func (p *changelogParser) checkReleased() bool {
 				releasedChange := &change{
 					Version:     &version,
 					VersionText: &versionText,
+					Date:        releaseDate(p.getDeformattedText()),
+					Yanked:      strings.HasSuffix(p.getDeformattedText(), yankedSuffix),
 				}
 				p.prefix = releasePrefix
 				p.currentChange = releasedChange
 				p.Released = append(p.Released, releasedChange)
+				p.startSpan()
 
 				return true
 			}
@@ -161,78 +171,101 @@ func (p *changelogParser) checkReleased() bool {
 }
 
 func (p *changelogParser) processChange() {
-	childText := loopChildren(p.CurrentNode, p.Changelog)
-
-	switch p.getDeformattedText() {
-	case strings.ToLower(string(changeAdded)):
-		p.currentChangeType = changeAdded
-		p.prefix = changePrefix
-	case strings.ToLower(string(changeChanged)):
-		p.currentChangeType = changeChanged
-		p.prefix = changePrefix
-	case strings.ToLower(string(changeDeprecated)):
-		p.currentChangeType = changeDeprecated
-		p.prefix = changePrefix
-	case strings.ToLower(string(changeRemoved)):
-		p.currentChangeType = changeRemoved
-		p.prefix = changePrefix
-	case strings.ToLower(string(changeFixed)):
-		p.currentChangeType = changeFixed
-		p.prefix = changePrefix
-	case strings.ToLower(string(changeSecurity)):
-		p.currentChangeType = changeSecurity
-		p.prefix = changePrefix
-	default:
-		if p.currentChangeType != "" && p.prefix != changePrefix {
-			p.processChangeEntries(childText)
+	if p.prefix == releasePrefix {
+		return
+	}
+
+	if p.CurrentNode.Kind() == ast.KindHeading {
+		if section := findChangeSection(*p.getCurrentText()); section != nil {
+			p.closeEntry(lineStart(p.Changelog, nodeStart(p.CurrentNode, p.Changelog)))
+			p.currentChangeType = section.Type
+			p.prefix = changePrefix
+			return
 		}
 	}
 
-	if p.prefix != "" {
-		p.buildChangeText(childText)
+	if p.currentChangeType != "" {
+		p.processChangeEntries(p.currentChange.sectionEntries(p.currentChangeType))
+	} else {
+		p.processChangeEntries(&p.currentChange.Description)
 	}
 }
 
-func (p *changelogParser) processChangeEntries(childText []string) {
-	switch p.currentChangeType {
-	case changeAdded:
-		p.currentChange.Added = append(p.currentChange.Added, childText...)
-		p.prefix = linePrefix
-	case changeChanged:
-		p.currentChange.Changed = append(p.currentChange.Changed, childText...)
-		p.prefix = linePrefix
-	case changeDeprecated:
-		p.currentChange.Deprecated = append(p.currentChange.Deprecated, childText...)
-		p.prefix = linePrefix
-	case changeRemoved:
-		p.currentChange.Removed = append(p.currentChange.Removed, childText...)
-		p.prefix = linePrefix
-	case changeFixed:
-		p.currentChange.Fixed = append(p.currentChange.Fixed, childText...)
-		p.prefix = linePrefix
-	case changeSecurity:
-		p.currentChange.Security = append(p.currentChange.Security, childText...)
+// processChangeEntries records the markdown source of each list item, or of any other block, as
+// entries so that nested lists, code blocks, and inline formatting are kept as they were written
+func (p *changelogParser) processChangeEntries(entries *[]string) {
+	items := []ast.Node{p.CurrentNode}
+	if p.CurrentNode.Kind() == ast.KindList {
+		items = []ast.Node{}
+		for item := p.CurrentNode.FirstChild(); item != nil; item = item.NextSibling() {
+			items = append(items, item)
+		}
+	}
+
+	for _, item := range items {
+		itemStart := nodeStart(item, p.Changelog)
+		itemStop := nodeStop(item, p.Changelog)
+		if itemStart < 0 || itemStop < itemStart {
+			continue
+		}
+		itemStart = lineStart(p.Changelog, itemStart)
+
+		p.closeEntry(itemStart)
+		*entries = append(*entries, strings.TrimSuffix(string(p.Changelog[itemStart:itemStop]), "\n"))
 		p.prefix = linePrefix
+		p.lastEntries = entries
+		p.lastEntryEnd = itemStop
+	}
+}
+
+// closeEntry carries any blank lines between the last entry and the next block over to the entry,
+// so that loose lists and spacing between sections survive being rendered again
+func (p *changelogParser) closeEntry(nextStart int) {
+	if p.lastEntries != nil && len(*p.lastEntries) > 0 && nextStart > p.lastEntryEnd {
+		gap := string(p.Changelog[p.lastEntryEnd:nextStart])
+		if strings.TrimSpace(gap) == "" {
+			(*p.lastEntries)[len(*p.lastEntries)-1] += gap
+		}
+	}
+
+	p.lastEntries = nil
+}
+
+func (p *changelogParser) startSpan() {
+	p.prefix = releasePrefix
+	p.currentChangeType = ""
+	p.lastEntries = nil
+	p.currentChange.Span = &sourceSpan{
+		Start: lineStart(p.Changelog, nodeStart(p.CurrentNode, p.Changelog)),
 	}
+	p.extendSpan()
 }
 
-func (p *changelogParser) buildChangeText(childText []string) {
-	fullText := p.prefix + *p.getCurrentText()
-	if p.prefix == linePrefix {
-		sb := strings.Builder{}
-		for _, cText := range childText {
-			sb.WriteString(p.prefix)
-			sb.WriteString(cText)
-			sb.WriteString("\n")
+func (p *changelogParser) extendSpan() {
+	if stop := nodeStop(p.CurrentNode, p.Changelog); stop > p.currentChange.Span.End {
+		p.currentChange.Span.End = stop
+	}
+}
+
+func (p *changelogParser) setSourceText() {
+	setText := func(change *change) {
+		if change == nil || change.Span == nil {
+			return
 		}
-		fullText = sb.String()
-		fullText = fullText[:len(fullText)-1]
+
+		textStart := lineEnd(p.Changelog, change.Span.Start+1)
+		if textStart > change.Span.End {
+			textStart = change.Span.End
+		}
+
+		text := strings.Trim(string(p.Changelog[textStart:change.Span.End]), "\n")
+		change.Text = &text
 	}
 
-	if p.currentChange.Text != nil {
-		fullText = *p.currentChange.Text + "\n" + fullText
+	setText(p.Unreleased)
+	for _, release := range p.Released {
+		setText(release)
 	}
-	p.currentChange.Text = &fullText
 }
 
 func parseChangelog(changelogFile string) ([]byte, *change, *string, []*change, error) {
@@ -284,11 +317,14 @@ func loopNodes(currentNode ast.Node, changelog []byte) (*changelogParser, error)
 
 		if clogParser.currentChange != nil {
 			clogParser.processChange()
+			clogParser.extendSpan()
 		}
 
 		clogParser.resetLoop()
 	}
 
+	clogParser.setSourceText()
+
 	return clogParser, nil
 }
 
@@ -308,6 +344,16 @@ func readChangelogFile(changelogFile string) ([]byte, error) {
 	return changelog, nil
 }
 
+var releaseDateRegex = regexp.MustCompile(`]-([0-9]{4}-[0-9]{2}-[0-9]{2})`)
+
+func releaseDate(deformattedText string) string {
+	if matches := releaseDateRegex.FindStringSubmatch(deformattedText); matches != nil {
+		return matches[1]
+	}
+
+	return ""
+}
+
 func parsingRegexes() (*regexp.Regexp, *regexp.Regexp, *regexp.Regexp, *regexp.Regexp, error) {
 	unreleasedRegex, err := regexp.Compile(`\[unreleased]$`)
 	if err != nil {
@@ -317,7 +363,7 @@ func parsingRegexes() (*regexp.Regexp, *regexp.Regexp, *regexp.Regexp, *regexp.R
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
-	releasedRegex, err := regexp.Compile(`\[\d+\.\d+\.\d+]-[0-9]{4}-[0-9]{2}-[0-9]{2}$`)
+	releasedRegex, err := regexp.Compile(`\[\d+\.\d+\.\d+(-[0-9a-z-]+(\.[0-9a-z-]+)*)?(\+[0-9a-z-]+(\.[0-9a-z-]+)*)?]-[0-9]{4}-[0-9]{2}-[0-9]{2}(\[yanked])?$`)
 	if err != nil {
 		return nil, nil, nil, nil, err
 	}
@@ -328,13 +374,21 @@ func parsingRegexes() (*regexp.Regexp, *regexp.Regexp, *regexp.Regexp, *regexp.R
 	return unreleasedRegex, unreleasedIncrementRegex, releasedRegex, versionRegex, nil
 }
 
-func writeToChangelogFile(file string, unreleased *change, released []*change, update bool) error {
+func releaseHeading(release *change) string {
+	heading := fmt.Sprintf("%s[%s] - %s", releasePrefix, release.Version.String(), release.Date)
+	if release.Yanked {
+		heading = heading + " [YANKED]"
+	}
+
+	return heading + "\n"
+}
+
+func writeToChangelogFile(file string, changelog []byte, unreleased *change, released []*change, update bool, removed ...*change) error {
 	sb := strings.Builder{}
-	sb.WriteString(changelogHeader)
 	if update {
-		sb.WriteString(fmt.Sprintf("%s[%s] - %s\n", releasePrefix, unreleased.Version.String(), time.Now().Format("2006-01-02")))
+		sb.WriteString(fmt.Sprintf("%s[%s] - %s\n", releasePrefix, unreleased.Version.String(), time.Now().Format(dateFormat)))
 	} else {
-		sb.WriteString(*unreleased.VersionText)
+		sb.WriteString(strings.TrimSuffix(*unreleased.VersionText, "\n") + "\n")
 	}
 
 	if unreleased.Text == nil || *unreleased.Text == "" || *unreleased.Text == "\n" {
@@ -346,15 +400,39 @@ func writeToChangelogFile(file string, unreleased *change, released []*change, u
 
 	sLogger.Debug(sb.String())
 
-	for _, release := range released {
-		sb.WriteString("\n")
-		sb.WriteString(*release.VersionText)
-		sb.WriteString("\n")
-		sb.WriteString(*release.Text)
-		sb.WriteString("\n")
+	edits := []changelogEdit{}
+	if unreleased.Span != nil {
+		edits = append(edits, changelogEdit{
+			Start: unreleased.Span.Start,
+			End:   unreleased.Span.End,
+			Text:  sb.String(),
+		})
+	} else {
+		insertAt := contentEnd(changelog)
+		for _, release := range released {
+			if release.Span != nil && release.Span.Start < insertAt {
+				insertAt = release.Span.Start
+			}
+		}
+
+		edits = append(edits, changelogEdit{
+			Start:  insertAt,
+			End:    insertAt,
+			Text:   sb.String(),
+			Insert: true,
+		})
+	}
+
+	for _, release := range removed {
+		if release.Span != nil {
+			edits = append(edits, changelogEdit{
+				Start: release.Span.Start,
+				End:   lineStartAfterBlankLines(changelog, release.Span.End),
+			})
+		}
 	}
 
-	if err := os.WriteFile(file, []byte(sb.String()), 0644); err != nil {
+	if err := os.WriteFile(file, applyChangelogEdits(changelog, edits), 0644); err != nil {
 		sLogger.Errorf("failed to write to changelog file %s", file)
 		return err
 	}
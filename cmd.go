@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"sync"
 	"syscall"
 )
 
@@ -39,9 +40,14 @@ func runCommand(dir, name string, arg ...string) (*string, int, error) {
 		return nil, exitCode, err
 	}
 
+	// Both pipes must be fully read before waiting, as wait closes them
+	var readers sync.WaitGroup
+	readers.Add(2)
+
 	scannerStdOut := bufio.NewScanner(stdOut)
 	sbStdOut := strings.Builder{}
 	go func() {
+		defer readers.Done()
 		for scannerStdOut.Scan() {
 			sbStdOut.WriteString(fmt.Sprintf("%s\n", scannerStdOut.Text()))
 		}
@@ -50,11 +56,13 @@ func runCommand(dir, name string, arg ...string) (*string, int, error) {
 	scannerStdErr := bufio.NewScanner(stdErr)
 	sbStdErr := strings.Builder{}
 	go func() {
+		defer readers.Done()
 		for scannerStdErr.Scan() {
 			sbStdErr.WriteString(fmt.Sprintf("%s\n", strings.TrimSpace(scannerStdErr.Text())))
 		}
 	}()
 
+	readers.Wait()
 	cmd.Wait()
 
 	stdOutString := strings.TrimSpace(sbStdOut.String())
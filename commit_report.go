@@ -0,0 +1,336 @@
+package main
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	reportFormatJUnit  = "junit"
+	reportFormatSarif  = "sarif"
+	reportFormatGitHub = "github"
+	reportFormatJSON   = "json"
+
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	toolURL      = "https://github.com/marmotherder/changehelper"
+)
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+
+	return hash
+}
+
+type jsonReport struct {
+	Passed  bool               `json:"passed"`
+	Commits []commitLintResult `json:"commits"`
+}
+
+func renderJSONReport(results []commitLintResult) ([]byte, error) {
+	report := jsonReport{
+		Passed:  true,
+		Commits: results,
+	}
+	for _, result := range results {
+		if result.hasSeverity(ruleSeverityError) {
+			report.Passed = false
+		}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	return append(data, '\n'), err
+}
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// renderJUnitReport renders a test case per commit, failing if the commit broke a rule with the error
+// severity, where any warnings are written to the output of the test case
+func renderJUnitReport(results []commitLintResult) ([]byte, error) {
+	suite := junitTestSuite{
+		Name:      "enforce-conventional-commits",
+		Tests:     len(results),
+		TestCases: []junitTestCase{},
+	}
+
+	for _, result := range results {
+		testCase := junitTestCase{
+			Name:      fmt.Sprintf("%s %s", shortHash(result.Hash), result.Header),
+			ClassName: "conventional-commits",
+		}
+
+		errors, warnings := []string{}, []string{}
+		errorRules := []string{}
+		for _, violation := range result.Violations {
+			line := fmt.Sprintf("%s %s: %s", violation.Severity, violation.Rule, violation.Message)
+			if violation.Severity == ruleSeverityError {
+				errors = append(errors, line)
+				errorRules = append(errorRules, violation.Rule)
+			} else {
+				warnings = append(warnings, line)
+			}
+		}
+
+		if len(errors) > 0 {
+			suite.Failures++
+			testCase.Failure = &junitFailure{
+				Message: fmt.Sprintf("commit %s broke the rules: %s", result.Hash, strings.Join(errorRules, ", ")),
+				Type:    "CommitRuleViolation",
+				Text:    strings.Join(errors, "\n"),
+			}
+		}
+		testCase.SystemOut = strings.Join(warnings, "\n")
+
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	report := junitTestSuites{
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Suites:   []junitTestSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	return append([]byte(xml.Header), append(data, '\n')...), err
+}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine int `json:"startLine"`
+}
+
+type sarifLogicalLocation struct {
+	Name               string `json:"name"`
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// renderSarifReport renders a result per broken rule, located by the commit that broke it. Commits have
+// no file to point at, so each result is placed on the changelog file, as code scanning needs a file,
+// with the commit in its fingerprint so results for different commits aren't merged
+func renderSarifReport(results []commitLintResult, changelogFile string) ([]byte, error) {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifDriver{
+				Name:           "changehelper",
+				Version:        version,
+				InformationURI: toolURL,
+				Rules:          []sarifRule{},
+			},
+		},
+		Results: []sarifResult{},
+	}
+
+	seenRules := map[string]bool{}
+	for _, result := range results {
+		for _, violation := range result.Violations {
+			if !seenRules[violation.Rule] {
+				seenRules[violation.Rule] = true
+
+				description := conventionalCommitRuleDescription
+				if definition, ok := commitRuleDefinitions[violation.Rule]; ok {
+					description = definition.Description
+				}
+				run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+					ID:               violation.Rule,
+					ShortDescription: sarifMessage{Text: description},
+				})
+			}
+
+			level := "error"
+			if violation.Severity == ruleSeverityWarn {
+				level = "warning"
+			}
+
+			run.Results = append(run.Results, sarifResult{
+				RuleID:  violation.Rule,
+				Level:   level,
+				Message: sarifMessage{Text: fmt.Sprintf("Commit %s '%s': %s", shortHash(result.Hash), result.Header, violation.Message)},
+				Locations: []sarifLocation{{
+					PhysicalLocation: sarifPhysicalLocation{
+						ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(filepath.Clean(changelogFile))},
+						Region:           sarifRegion{StartLine: 1},
+					},
+					LogicalLocations: []sarifLogicalLocation{{
+						Name:               shortHash(result.Hash),
+						FullyQualifiedName: result.Hash,
+						Kind:               "commit",
+					}},
+				}},
+				PartialFingerprints: map[string]string{
+					"commitSha": result.Hash,
+				},
+			})
+		}
+	}
+
+	data, err := json.MarshalIndent(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	}, "", "  ")
+	return append(data, '\n'), err
+}
+
+// escapeGitHubData escapes the message of a github workflow command
+func escapeGitHubData(data string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(data)
+}
+
+// escapeGitHubProperty escapes a property of a github workflow command, eg. its title
+func escapeGitHubProperty(property string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A", ":", "%3A", ",", "%2C").Replace(property)
+}
+
+// renderGitHubReport renders a github actions workflow command per broken rule, so that each is shown
+// as an error or warning annotation on the run
+func renderGitHubReport(results []commitLintResult) ([]byte, error) {
+	sb := strings.Builder{}
+	for _, result := range results {
+		for _, violation := range result.Violations {
+			command := "error"
+			if violation.Severity == ruleSeverityWarn {
+				command = "warning"
+			}
+
+			sb.WriteString(fmt.Sprintf("::%s title=%s::%s\n",
+				command,
+				escapeGitHubProperty(fmt.Sprintf("%s (%s)", violation.Rule, shortHash(result.Hash))),
+				escapeGitHubData(fmt.Sprintf("Commit %s '%s': %s", result.Hash, result.Header, violation.Message)),
+			))
+		}
+	}
+
+	return []byte(sb.String()), nil
+}
+
+// validateReportFormat checks that the format is one that a report can be rendered in
+func validateReportFormat(format string) error {
+	switch strings.ToLower(format) {
+	case reportFormatJUnit, reportFormatSarif, reportFormatGitHub, reportFormatJSON:
+		return nil
+	case "":
+		return fmt.Errorf("no report format is set, it must be one of %s, %s, %s or %s", reportFormatJUnit, reportFormatSarif, reportFormatGitHub, reportFormatJSON)
+	default:
+		return fmt.Errorf("report format %s is not supported, only %s, %s, %s and %s are supported", format, reportFormatJUnit, reportFormatSarif, reportFormatGitHub, reportFormatJSON)
+	}
+}
+
+// writeCommitLintReport renders the results in the format, writing them to the file, or printing them if
+// there is no file set
+func writeCommitLintReport(results []commitLintResult, format, file, changelogFile string) error {
+	if err := validateReportFormat(format); err != nil {
+		return err
+	}
+
+	var data []byte
+	var err error
+	switch strings.ToLower(format) {
+	case reportFormatJUnit:
+		data, err = renderJUnitReport(results)
+	case reportFormatSarif:
+		data, err = renderSarifReport(results, changelogFile)
+	case reportFormatGitHub:
+		data, err = renderGitHubReport(results)
+	case reportFormatJSON:
+		data, err = renderJSONReport(results)
+	}
+	if err != nil {
+		return err
+	}
+
+	if file == "" {
+		fmt.Print(string(data))
+		return nil
+	}
+
+	if err := os.WriteFile(file, data, 0644); err != nil {
+		sLogger.Errorf("failed to write the report to %s", file)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,293 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+const (
+	ruleSeverityError = "error"
+	ruleSeverityWarn  = "warn"
+
+	// conventionalCommitRule is reported for messages that aren't conventional commits, before any
+	// configured rules are checked
+	conventionalCommitRule            = "conventional-commit"
+	conventionalCommitRuleDescription = "The message must be a conventional commit, with a supported type"
+
+	subjectLowerCase    = "lower-case"
+	subjectSentenceCase = "sentence-case"
+)
+
+var signedOffByRegex = regexp.MustCompile(`(?im)^signed-off-by:[ \t]*\S`)
+
+// commitRuleDefinition is a rule that can be configured, where the check returns the problem found with
+// the commit, or an empty string if it passes
+type commitRuleDefinition struct {
+	Description string
+	NeedsValue  bool
+	Validate    func(value string) error
+	Check       func(value, message string, commit *conventionalcommits.ConventionalCommit) string
+}
+
+// listValues splits a comma separated rule value, eg. feat,fix
+func listValues(value string) []string {
+	values := []string{}
+	for _, listValue := range strings.Split(value, ",") {
+		if listValue = strings.ToLower(strings.TrimSpace(listValue)); listValue != "" {
+			values = append(values, listValue)
+		}
+	}
+
+	return values
+}
+
+func containsValue(values []string, value string) bool {
+	for _, existing := range values {
+		if existing == strings.ToLower(strings.TrimSpace(value)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+var commitRuleDefinitions = map[string]commitRuleDefinition{
+	"type-enum": {
+		Description: "The type must be one of the allowed types",
+		NeedsValue:  true,
+		Check: func(value, _ string, commit *conventionalcommits.ConventionalCommit) string {
+			if !containsValue(listValues(value), commit.Type) {
+				return fmt.Sprintf("type %s is not one of %s", commit.Type, strings.Join(listValues(value), ", "))
+			}
+			return ""
+		},
+	},
+	"scope-enum": {
+		Description: "Each scope must be one of the allowed scopes",
+		NeedsValue:  true,
+		Check: func(value, _ string, commit *conventionalcommits.ConventionalCommit) string {
+			if commit.Scope == nil {
+				return ""
+			}
+			for _, scope := range strings.Split(*commit.Scope, ",") {
+				if !containsValue(listValues(value), scope) {
+					return fmt.Sprintf("scope %s is not one of %s", strings.TrimSpace(scope), strings.Join(listValues(value), ", "))
+				}
+			}
+			return ""
+		},
+	},
+	"scope-required": {
+		Description: "The commit must have a scope",
+		Check: func(_, _ string, commit *conventionalcommits.ConventionalCommit) string {
+			if commit.Scope == nil || strings.TrimSpace(*commit.Scope) == "" {
+				return "scope is required"
+			}
+			return ""
+		},
+	},
+	"header-max-length": {
+		Description: "The header must be no longer than the maximum length",
+		NeedsValue:  true,
+		Validate: func(value string) error {
+			if maxLength, err := strconv.Atoi(value); err != nil || maxLength <= 0 {
+				return fmt.Errorf("'%s' is not a positive number", value)
+			}
+			return nil
+		},
+		Check: func(value, message string, _ *conventionalcommits.ConventionalCommit) string {
+			maxLength, _ := strconv.Atoi(value)
+			if headerLength := utf8.RuneCountInString(commitHeader(message)); headerLength > maxLength {
+				return fmt.Sprintf("header is %d characters, longer than the maximum of %d", headerLength, maxLength)
+			}
+			return ""
+		},
+	},
+	"subject-case": {
+		Description: "The subject must start with the configured case",
+		NeedsValue:  true,
+		Validate: func(value string) error {
+			if value != subjectLowerCase && value != subjectSentenceCase {
+				return fmt.Errorf("'%s' is not a supported case, only %s and %s are supported", value, subjectLowerCase, subjectSentenceCase)
+			}
+			return nil
+		},
+		Check: func(value, _ string, commit *conventionalcommits.ConventionalCommit) string {
+			first, _ := utf8.DecodeRuneInString(strings.TrimSpace(commit.Description))
+			if value == subjectLowerCase && unicode.IsUpper(first) {
+				return "subject must start with a lower case letter"
+			}
+			if value == subjectSentenceCase && unicode.IsLower(first) {
+				return "subject must start with an upper case letter"
+			}
+			return ""
+		},
+	},
+	"subject-no-full-stop": {
+		Description: "The subject must not end with a full stop",
+		Check: func(_, _ string, commit *conventionalcommits.ConventionalCommit) string {
+			if strings.HasSuffix(strings.TrimSpace(commit.Description), ".") {
+				return "subject must not end with a full stop"
+			}
+			return ""
+		},
+	},
+	"signed-off-by": {
+		Description: "The message must have a Signed-off-by trailer",
+		Check: func(_, message string, _ *conventionalcommits.ConventionalCommit) string {
+			if !signedOffByRegex.MatchString(message) {
+				return "message must have a Signed-off-by trailer"
+			}
+			return ""
+		},
+	},
+}
+
+// commitRule is a rule configured for linting commits, with the severity it is reported with
+type commitRule struct {
+	Name     string
+	Severity string
+	Value    string
+}
+
+// parseCommitRules loads the rules from their cli form, <name>[:<severity>][=<value>], eg. header-max-length:warn=72,
+// where the severity is error by default
+func parseCommitRules(rules []string) ([]commitRule, error) {
+	parsed := []commitRule{}
+	for _, rule := range rules {
+		ruleSplit := strings.SplitN(rule, "=", 2)
+		nameSplit := strings.SplitN(strings.TrimSpace(ruleSplit[0]), ":", 2)
+
+		commitRule := commitRule{
+			Name:     strings.ToLower(strings.TrimSpace(nameSplit[0])),
+			Severity: ruleSeverityError,
+		}
+		if len(nameSplit) > 1 {
+			commitRule.Severity = strings.ToLower(strings.TrimSpace(nameSplit[1]))
+		}
+		if len(ruleSplit) > 1 {
+			commitRule.Value = strings.TrimSpace(ruleSplit[1])
+		}
+
+		definition, ok := commitRuleDefinitions[commitRule.Name]
+		if !ok {
+			return nil, fmt.Errorf("rule '%s' is not a supported rule", rule)
+		}
+		if commitRule.Severity != ruleSeverityError && commitRule.Severity != ruleSeverityWarn {
+			return nil, fmt.Errorf("rule '%s' has the severity %s, only %s and %s are supported", rule, commitRule.Severity, ruleSeverityError, ruleSeverityWarn)
+		}
+		if definition.NeedsValue && commitRule.Value == "" {
+			return nil, fmt.Errorf("rule '%s' needs a value, in the form %s=<value>", rule, commitRule.Name)
+		}
+		if definition.Validate != nil {
+			if err := definition.Validate(commitRule.Value); err != nil {
+				return nil, fmt.Errorf("rule '%s' has an invalid value, %w", rule, err)
+			}
+		}
+
+		parsed = append(parsed, commitRule)
+	}
+
+	return parsed, nil
+}
+
+// commitRuleViolation is a rule broken by a commit
+type commitRuleViolation struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// commitLintResult is the outcome of linting a single commit, with each rule it broke
+type commitLintResult struct {
+	Hash       string                `json:"hash"`
+	Header     string                `json:"header"`
+	Violations []commitRuleViolation `json:"violations"`
+}
+
+func (r commitLintResult) hasSeverity(severity string) bool {
+	for _, violation := range r.Violations {
+		if violation.Severity == severity {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lintCommit checks that the full message is a conventional commit, and then checks it against each of
+// the rules
+func lintCommit(machine conventionalcommits.Machine, rules []commitRule, hash, message, parseSeverity string) commitLintResult {
+	result := commitLintResult{
+		Hash:       hash,
+		Header:     commitHeader(message),
+		Violations: []commitRuleViolation{},
+	}
+
+	ccCommit, err := validateCommitMessage(machine, message)
+	if err != nil {
+		result.Violations = append(result.Violations, commitRuleViolation{
+			Rule:     conventionalCommitRule,
+			Severity: parseSeverity,
+			Message:  err.Error(),
+		})
+		return result
+	}
+
+	for _, rule := range rules {
+		if problem := commitRuleDefinitions[rule.Name].Check(rule.Value, message, ccCommit); problem != "" {
+			result.Violations = append(result.Violations, commitRuleViolation{
+				Rule:     rule.Name,
+				Severity: rule.Severity,
+				Message:  problem,
+			})
+		}
+	}
+
+	return result
+}
+
+// commitLintReport renders the failing commits, each followed by the rules it broke, where a single
+// result without a hash is a message being linted before it is committed
+func commitLintReport(results []commitLintResult) string {
+	sb := strings.Builder{}
+	if len(results) == 1 && results[0].Hash == "" {
+		sb.WriteString("the commit message was not found to adhere to conventional commit principles\n\n")
+	} else {
+		sb.WriteString("not all commits were found to adhere to conventional commit principles\n\n")
+	}
+
+	for _, result := range results {
+		if len(result.Violations) == 0 {
+			continue
+		}
+
+		if result.Hash != "" {
+			sb.WriteString(fmt.Sprintf("Commit: %s in message: %s\n", result.Hash, result.Header))
+		} else {
+			sb.WriteString(fmt.Sprintf("Message: %s\n", result.Header))
+		}
+		for _, violation := range result.Violations {
+			sb.WriteString(fmt.Sprintf("  %s %s: %s\n", violation.Severity, violation.Rule, violation.Message))
+		}
+	}
+
+	return sb.String()
+}
+
+// mustParseCommitRules loads the rules from the options, exiting if any are invalid
+func mustParseCommitRules(options CommitRuleOptions) []commitRule {
+	rules, err := parseCommitRules(options.Rules)
+	if err != nil {
+		sLogger.Error("failed to load the commit rules")
+		sLogger.Fatal(err.Error())
+	}
+
+	return rules
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+const contributorsPrefix = "Contributors: "
+
+var coAuthoredByRegex = regexp.MustCompile(`(?im)^co-authored-by:[ \t]*(.*?)[ \t]*<([^>]*)>[ \t]*$`)
+
+// contactRegex splits a contact, as mapped by check-mailmap, into its name and email, eg. 'Jane Doe <jane@example.com>'
+var contactRegex = regexp.MustCompile(`^(.*?)\s*<([^>]*)>$`)
+
+// gitContributor is an author, or co-author, of a commit, as mapped by the mailmap of the repository
+type gitContributor struct {
+	Name  string
+	Email string
+}
+
+func (c gitContributor) key() string {
+	if c.Email != "" {
+		return strings.ToLower(c.Email)
+	}
+
+	return strings.ToLower(c.Name)
+}
+
+// displayName is the name the contributor is credited with, falling back to their email
+func (c gitContributor) displayName() string {
+	if c.Name != "" {
+		return c.Name
+	}
+
+	return c.Email
+}
+
+// appendContributors adds the contributors that aren't already in the list, by their email, or their
+// name if they have no email
+func appendContributors(existing []gitContributor, contributors ...gitContributor) []gitContributor {
+	for _, contributor := range contributors {
+		if contributor.Name == "" && contributor.Email == "" {
+			continue
+		}
+
+		isDuplicate := false
+		for _, existingContributor := range existing {
+			if existingContributor.key() == contributor.key() {
+				isDuplicate = true
+				break
+			}
+		}
+
+		if !isDuplicate {
+			existing = append(existing, contributor)
+		}
+	}
+
+	return existing
+}
+
+// resolveCommitContributors finds the author of the commit, followed by any co-authors from its
+// Co-authored-by trailers, which are mapped through the mailmap in the same way git maps the author
+func resolveCommitContributors(commit gitCommit, git gitCli) []gitContributor {
+	contributors := appendContributors([]gitContributor{}, gitContributor{
+		Name:  commit.AuthorName,
+		Email: commit.AuthorEmail,
+	})
+
+	coAuthors := []gitContributor{}
+	contacts := []string{}
+	for _, match := range coAuthoredByRegex.FindAllStringSubmatch(commit.Body, -1) {
+		coAuthors = append(coAuthors, gitContributor{
+			Name:  match[1],
+			Email: match[2],
+		})
+		contacts = append(contacts, fmt.Sprintf("%s <%s>", match[1], match[2]))
+	}
+	if len(coAuthors) == 0 {
+		return contributors
+	}
+
+	mappedContacts, err := git.checkMailmap(contacts...)
+	if err != nil || len(mappedContacts) != len(coAuthors) {
+		sLogger.Warnf("failed to map the co-authors of commit %s, using them as they are written", commit.Hash)
+		return appendContributors(contributors, coAuthors...)
+	}
+
+	for idx, mappedContact := range mappedContacts {
+		if match := contactRegex.FindStringSubmatch(mappedContact); match != nil {
+			coAuthors[idx] = gitContributor{
+				Name:  match[1],
+				Email: match[2],
+			}
+		}
+	}
+
+	return appendContributors(contributors, coAuthors...)
+}
+
+// contributorsText lists the names of the contributors, eg. 'Jane Doe, John Roe and Max Mustermann'
+func contributorsText(contributors []gitContributor) string {
+	names := []string{}
+	for _, contributor := range contributors {
+		names = append(names, contributor.displayName())
+	}
+
+	if len(names) <= 1 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+}
+
+// creditEntry credits the contributors at the end of the first line of the entry, eg.
+// '- support pagination by Jane Doe'
+func creditEntry(entry string, contributors []gitContributor) string {
+	if len(contributors) == 0 {
+		return entry
+	}
+
+	entryLines := strings.SplitN(entry, "\n", 2)
+	entryLines[0] = fmt.Sprintf("%s by %s", entryLines[0], contributorsText(contributors))
+
+	return strings.Join(entryLines, "\n")
+}
+
+// addContributors records the names of the contributors in the description of the change, on a single
+// 'Contributors: ' line sorted by name, merging them with any names already listed
+func (c *change) addContributors(names ...string) {
+	for idx, description := range c.Description {
+		descriptionLines := strings.Split(description, "\n")
+		for lineIdx, line := range descriptionLines {
+			if !strings.HasPrefix(line, contributorsPrefix) {
+				continue
+			}
+
+			descriptionLines[lineIdx] = contributorsLine(append(strings.Split(strings.TrimPrefix(line, contributorsPrefix), ", "), names...))
+			c.Description[idx] = strings.Join(descriptionLines, "\n")
+			return
+		}
+	}
+
+	if len(names) == 0 {
+		return
+	}
+
+	if last := len(c.Description) - 1; last >= 0 && !strings.HasSuffix(c.Description[last], "\n") {
+		c.Description[last] += "\n"
+	}
+	c.Description = append(c.Description, contributorsLine(names))
+}
+
+func contributorsLine(names []string) string {
+	uniqueNames := []string{}
+	seen := map[string]bool{}
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[strings.ToLower(name)] {
+			continue
+		}
+		seen[strings.ToLower(name)] = true
+		uniqueNames = append(uniqueNames, name)
+	}
+
+	sort.SliceStable(uniqueNames, func(i, j int) bool {
+		return strings.ToLower(uniqueNames[i]) < strings.ToLower(uniqueNames[j])
+	})
+
+	return contributorsPrefix + strings.Join(uniqueNames, ", ")
+}
+
+// contributorNames lists the names of the contributors found in the description of the change
+func (c *change) contributorNames() []string {
+	for _, description := range c.Description {
+		for _, line := range strings.Split(description, "\n") {
+			if strings.HasPrefix(line, contributorsPrefix) {
+				return strings.Split(strings.TrimPrefix(line, contributorsPrefix), ", ")
+			}
+		}
+	}
+
+	return nil
+}
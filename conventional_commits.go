@@ -3,6 +3,7 @@ package main
 import (
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/leodido/go-conventionalcommits"
@@ -20,91 +21,429 @@ const (
 	conventionalCommitRefactor conventionalCommitType = "refactor"
 	conventionalCommitPerf     conventionalCommitType = "perf"
 	conventionalCommitTest     conventionalCommitType = "test"
+	conventionalCommitRevert   conventionalCommitType = "revert"
 )
 
 type conventionalCommitType string
 
-func getConventionalCommitType(message string) *conventionalCommitType {
-	supportedTypes := []conventionalCommitType{
-		conventionalCommitFix,
-		conventionalCommitFeat,
-		conventionalCommitBuild,
-		conventionalCommitChore,
-		conventionalCommitCI,
-		conventionalCommitDocs,
-		conventionalCommitStyle,
-		conventionalCommitRefactor,
-		conventionalCommitPerf,
-		conventionalCommitTest,
-	}
-
-	for _, supportedType := range supportedTypes {
-		scopedRegex := regexp.MustCompile(fmt.Sprintf(`%s\([^)]*\):`, supportedType))
-		breakingScopedRegex := regexp.MustCompile(fmt.Sprintf(`%s\([^)]*\)!:`, supportedType))
-		if strings.Contains(message, string(supportedType)+":") ||
-			strings.Contains(message, string(supportedType)+"!:") ||
-			scopedRegex.MatchString(message) ||
-			breakingScopedRegex.MatchString(message) {
-			return &supportedType
+// commitTypeSections maps conventional commit types to the section their changes are recorded under,
+// where types without a mapping are recorded by how the files were changed
+var commitTypeSections = map[conventionalCommitType]changeType{}
+
+// excludedCommitTypes are the conventional commit types that are not recorded in the changelog
+var excludedCommitTypes = map[conventionalCommitType]bool{}
+
+// configureCommitTypes loads the mappings of types to sections from their cli form, <type>=<section>,
+// along with any types to exclude from the changelog, where each type must be a supported type
+func configureCommitTypes(typeSections, excludeTypes []string) error {
+	for _, typeSection := range typeSections {
+		typeSectionSplit := strings.SplitN(typeSection, "=", 2)
+		if len(typeSectionSplit) != 2 || strings.TrimSpace(typeSectionSplit[0]) == "" {
+			return fmt.Errorf("type section '%s' is not in the form <type>=<section>", typeSection)
+		}
+
+		section := findChangeSection(typeSectionSplit[1])
+		if section == nil {
+			return fmt.Errorf("type section '%s' maps to a section that is not configured", typeSection)
+		}
+
+		commitType := conventionalCommitType(strings.ToLower(strings.TrimSpace(typeSectionSplit[0])))
+		if !isSupportedCommitType(commitType) {
+			return fmt.Errorf("type section '%s' maps the type %s, which is not supported, custom types are set with --commit-type", typeSection, commitType)
+		}
+
+		commitTypeSections[commitType] = section.Type
+	}
+
+	for _, excludeType := range excludeTypes {
+		commitType := conventionalCommitType(strings.ToLower(strings.TrimSpace(excludeType)))
+		if !isSupportedCommitType(commitType) {
+			return fmt.Errorf("excluded type '%s' is not supported, custom types are set with --commit-type", excludeType)
 		}
+
+		excludedCommitTypes[commitType] = true
 	}
 
+	sLogger.Debugf("configured conventional commit types to sections as %v, excluding %v", commitTypeSections, excludedCommitTypes)
+
 	return nil
 }
 
-func parseConventionalCommitMessages(commitMessages ...string) (*string, map[int]conventionalCommitType) {
-	var increment string
-	mappedTypes := map[int]conventionalCommitType{}
-	machineOptions := []conventionalcommits.MachineOption{
-		conventionalcommits.WithTypes(conventionalcommits.TypesConventional),
+var builtInCommitTypes = []conventionalCommitType{
+	conventionalCommitFix,
+	conventionalCommitFeat,
+	conventionalCommitBuild,
+	conventionalCommitChore,
+	conventionalCommitCI,
+	conventionalCommitDocs,
+	conventionalCommitStyle,
+	conventionalCommitRefactor,
+	conventionalCommitPerf,
+	conventionalCommitTest,
+	conventionalCommitRevert,
+}
+
+// customCommitTypes are the conventional commit types supported on top of the built in types, with
+// the increment level each of them releases as
+var customCommitTypes = map[conventionalCommitType]string{}
+
+// configureCustomCommitTypes loads the custom types from their cli form, <type>[=<increment>], where the
+// increment defaults to PATCH, eg. security=PATCH
+func configureCustomCommitTypes(commitTypes []string) error {
+	for _, commitType := range commitTypes {
+		commitTypeSplit := strings.SplitN(commitType, "=", 2)
+		customType := conventionalCommitType(strings.ToLower(strings.TrimSpace(commitTypeSplit[0])))
+		if customType == "" {
+			return fmt.Errorf("commit type '%s' has no type set", commitType)
+		}
+
+		increment := PATCH
+		if len(commitTypeSplit) > 1 {
+			increment = strings.ToUpper(strings.TrimSpace(commitTypeSplit[1]))
+		}
+		if increment != MAJOR && increment != MINOR && increment != PATCH {
+			return fmt.Errorf("commit type '%s' has an increment that is not one of %s, %s, or %s", commitType, MAJOR, MINOR, PATCH)
+		}
+
+		customCommitTypes[customType] = increment
+	}
+
+	return nil
+}
+
+func isSupportedCommitType(commitType conventionalCommitType) bool {
+	if _, ok := customCommitTypes[commitType]; ok {
+		return true
+	}
+
+	for _, builtInType := range builtInCommitTypes {
+		if builtInType == commitType {
+			return true
+		}
+	}
+
+	return false
+}
+
+// commitTypeIncrement is the increment a non breaking commit of the type releases as
+func commitTypeIncrement(commitType conventionalCommitType) string {
+	if increment, ok := customCommitTypes[commitType]; ok {
+		return increment
+	}
+
+	if commitType == conventionalCommitFeat {
+		return MINOR
+	}
+
+	return PATCH
+}
+
+// newConventionalCommitMachine builds the parser for conventional commits. The conventional types are
+// used unless custom types are configured, where the parser is then free form, and the type is checked
+// against the supported types once parsed
+func newConventionalCommitMachine() conventionalcommits.Machine {
+	typeConfig := conventionalcommits.TypesConventional
+	if len(customCommitTypes) > 0 {
+		typeConfig = conventionalcommits.TypesFreeForm
+	}
+
+	return parser.NewMachine(
+		conventionalcommits.WithTypes(typeConfig),
 		conventionalcommits.WithBestEffort(),
+	)
+}
+
+func getConventionalCommitType(message string) *conventionalCommitType {
+	commit := parseConventionalCommit(message)
+	if commit == nil {
+		return nil
 	}
-	machine := parser.NewMachine(machineOptions...)
 
-	for idx, commitMessage := range commitMessages {
-		ccMessage, err := machine.Parse([]byte(commitMessage))
-		if err != nil {
-			sLogger.Debugf("failed to parse commit '%s' as conventional commit: %s", commitMessage, err.Error())
-			continue
+	commitType := conventionalCommitType(strings.ToLower(commit.Type))
+	if !isSupportedCommitType(commitType) {
+		return nil
+	}
+
+	return &commitType
+}
+
+// parseConventionalCommit parses a message as a conventional commit, returning nil if the message
+// does not have a valid conventional commit header
+func parseConventionalCommit(message string) *conventionalcommits.ConventionalCommit {
+	// In best effort mode, a message with a valid header but a malformed body or trailer is
+	// still returned, along with the error
+	ccMessage, err := newConventionalCommitMachine().Parse([]byte(message))
+	if err != nil {
+		sLogger.Debugf("failed to parse commit '%s' as conventional commit: %s", message, err.Error())
+	}
+	if ccMessage == nil || !ccMessage.Ok() {
+		return nil
+	}
+
+	commit, ok := ccMessage.(*conventionalcommits.ConventionalCommit)
+	if !ok {
+		return nil
+	}
+
+	return commit
+}
+
+// isConventionalCommit checks if a message parses as a conventional commit of a supported type
+func isConventionalCommit(message string) bool {
+	return getConventionalCommitType(message) != nil
+}
+
+// commitHeader is the first line of a commit message, where the type, scope and description are set
+func commitHeader(message string) string {
+	return strings.SplitN(message, "\n", 2)[0]
+}
+
+// getBreakingChanges returns the descriptions of the breaking changes in a commit, taken from any
+// BREAKING CHANGE footers, or the commit description when the header is only marked with a '!'
+func getBreakingChanges(commit *conventionalcommits.ConventionalCommit) []string {
+	if commit == nil || !commit.IsBreakingChange() {
+		return nil
+	}
+
+	breakingChanges := []string{}
+	for _, footer := range commit.Footers["breaking-change"] {
+		if footer = strings.TrimSpace(footer); footer != "" {
+			breakingChanges = append(breakingChanges, footer)
 		}
-		if !ccMessage.Ok() {
+	}
+
+	if len(breakingChanges) == 0 {
+		breakingChanges = append(breakingChanges, commit.Description)
+	}
+
+	return breakingChanges
+}
+
+func parseConventionalCommitMessages(commitMessages ...string) (*string, map[int]conventionalCommitType) {
+	var increment string
+	mappedTypes := map[int]conventionalCommitType{}
+	for idx, commitMessage := range commitMessages {
+		ccMessage := parseConventionalCommit(commitMessage)
+		if ccMessage == nil {
 			continue
 		}
 
-		ccType := getConventionalCommitType(commitMessage)
-		if ccType == nil {
+		ccType := conventionalCommitType(strings.ToLower(ccMessage.Type))
+		if !isSupportedCommitType(ccType) {
 			sLogger.Warnf("failed to find appropriate conventional commit type in message: %s, skipping", commitMessage)
 			continue
 		}
 
-		mappedTypes[idx] = *ccType
+		mappedTypes[idx] = ccType
 
+		// Excluded types aren't recorded, so only count towards the increment with a breaking change,
+		// which is still recorded
 		if ccMessage.IsBreakingChange() {
 			increment = MAJOR
 			sLogger.Debug("increment set as " + MAJOR)
+		} else if excludedCommitTypes[ccType] {
+			sLogger.Debugf("commit of excluded type %s does not count towards the increment", ccType)
 		} else if increment != MAJOR {
-			if *ccType == conventionalCommitFeat {
-				increment = MINOR
-				sLogger.Debug("increment set as " + MINOR)
-			} else if increment != MINOR {
-				increment = PATCH
-				sLogger.Debug("increment set as " + PATCH)
+			if typeIncrement := higherIncrement(increment, commitTypeIncrement(ccType)); typeIncrement != increment {
+				increment = typeIncrement
+				sLogger.Debug("increment set as " + increment)
 			}
 		}
 	}
 
 	if increment == "" {
-		sLogger.Fatal("failed to find an increment for a change")
+		sLogger.Debug("no increment was found in the commit messages")
+		return nil, mappedTypes
 	}
 
 	return &increment, mappedTypes
 }
 
-func resolveConventionalCommits(git gitCli, changelogFile string, depth int) (*string, map[string]string, map[string]string, map[string]string, map[string]string, error) {
+// conventionalCommitChanges are the changes resolved from a set of conventional commits, where the
+// files changed by the commits are mapped to their messages
+type conventionalCommitChanges struct {
+	Increment *string
+	Files     map[changeType]map[string]string
+	Breaking  []string
+	Commits   []conventionalCommitEntry
+	// FileCommits are the hashes of the commits behind each file entry, by section
+	FileCommits map[changeType]map[string][]string
+	// Contributors are the authors and co-authors of each commit, by hash
+	Contributors map[string][]gitContributor
+}
+
+func (c *conventionalCommitChanges) setFiles(sectionType changeType, files []string, commit gitCommit) {
+	if _, ok := c.Files[sectionType]; !ok {
+		c.Files[sectionType] = map[string]string{}
+		c.FileCommits[sectionType] = map[string][]string{}
+	}
+
+	setUnique(files, commit.Message, c.Files[sectionType])
+	for _, file := range files {
+		c.FileCommits[sectionType][file] = append(c.FileCommits[sectionType][file], commit.Hash)
+	}
+}
+
+// conventionalCommitEntry is a single conventional commit, used when entries are recorded per commit
+type conventionalCommitEntry struct {
+	Hash         string
+	Type         conventionalCommitType
+	Section      changeType
+	Scope        string
+	Description  string
+	Files        []string
+	Contributors []gitContributor
+}
+
+// commitFilter limits the conventional commits that are used to resolve a version, and its changes
+type commitFilter struct {
+	Scopes        []string
+	ExcludeScopes []string
+	Paths         []string
+}
+
+// newCommitFilter builds the filter from the options, where without any paths set, a git working
+// directory in a sub-directory of the repository limits the commits to those touching it
+func newCommitFilter(options CommitFilterOptions, git gitCli) commitFilter {
+	filter := commitFilter{
+		Scopes:        options.Scopes,
+		ExcludeScopes: options.ExcludeScopes,
+		Paths:         options.Paths,
+	}
+
+	if len(filter.Paths) == 0 {
+		prefix, err := git.getWorkdirPrefix()
+		if err != nil {
+			sLogger.Warn("failed to find the git working directory in the repository, commits will not be limited to it")
+			sLogger.Debug(err.Error())
+		} else if *prefix != "" {
+			sLogger.Debugf("limiting commits to the git working directory %s", *prefix)
+			filter.Paths = []string{"."}
+		}
+	}
+
+	return filter
+}
+
+// isSet checks if the filter limits the commits in any way
+func (f commitFilter) isSet() bool {
+	return len(f.Scopes) > 0 || len(f.ExcludeScopes) > 0 || len(f.Paths) > 0
+}
+
+// logArgs are the git log arguments for a commit range, limited to the commits touching the paths
+// of the filter, if any are set
+func (f commitFilter) logArgs(commitRange ...string) []string {
+	if len(f.Paths) == 0 {
+		return commitRange
+	}
+
+	return append(append(commitRange, "--"), f.Paths...)
+}
+
+// includes checks if the commit message passes the filter. A message can have multiple comma
+// separated scopes, eg. 'feat(api,web): ...', where any of them can match
+func (f commitFilter) includes(message string) bool {
+	if len(f.Scopes) == 0 && len(f.ExcludeScopes) == 0 {
+		return true
+	}
+
+	commit := parseConventionalCommit(message)
+	if commit == nil {
+		return false
+	}
+
+	scopes := []string{}
+	if commit.Scope != nil {
+		for _, scope := range strings.Split(*commit.Scope, ",") {
+			scopes = append(scopes, strings.ToLower(strings.TrimSpace(scope)))
+		}
+	}
+
+	hasScope := func(filterScopes []string) bool {
+		for _, filterScope := range filterScopes {
+			for _, scope := range scopes {
+				if strings.EqualFold(filterScope, scope) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	if hasScope(f.ExcludeScopes) {
+		return false
+	}
+
+	return len(f.Scopes) == 0 || hasScope(f.Scopes)
+}
+
+// filterCommits returns the commits that pass the filter
+func (f commitFilter) filterCommits(commits []gitCommit) []gitCommit {
+	filtered := []gitCommit{}
+	for _, commit := range commits {
+		if f.includes(commit.fullMessage()) {
+			filtered = append(filtered, commit)
+		} else {
+			sLogger.Debugf("commit %s does not match the commit filter, skipping", commit.Hash)
+		}
+	}
+
+	return filtered
+}
+
+var revertedCommitRegex = regexp.MustCompile(`(?i)this reverts commit ([0-9a-f]{7,40})`)
+
+// isRevertCommit checks if the commit is a revert written by git, eg. 'Revert "feat: add pagination"',
+// which names the commit it reverts in its body
+func isRevertCommit(commit gitCommit) bool {
+	return strings.HasPrefix(commit.Message, "Revert \"") && revertedCommitRegex.MatchString(commit.fullMessage())
+}
+
+// dropRevertedCommits removes the commits that are reverted by a later commit in the same range, along
+// with the commit reverting them, so neither is recorded, nor counts towards the increment. Reverting
+// a revert brings back the originally reverted commit. The commits are in git log order, newest first
+func dropRevertedCommits(commits []gitCommit) []gitCommit {
+	dropped := map[string]bool{}
+	revertedBy := map[string]string{}
+	for idx := len(commits) - 1; idx >= 0; idx-- {
+		revert := commits[idx]
+		for _, match := range revertedCommitRegex.FindAllStringSubmatch(revert.fullMessage(), -1) {
+			for _, reverted := range commits[idx+1:] {
+				if !strings.HasPrefix(reverted.Hash, strings.ToLower(match[1])) {
+					continue
+				}
+
+				if !dropped[reverted.Hash] {
+					sLogger.Infof("commit %s reverts commit %s in the same range, dropping both", revert.Hash, reverted.Hash)
+					dropped[reverted.Hash] = true
+					dropped[revert.Hash] = true
+					revertedBy[revert.Hash] = reverted.Hash
+				} else if original, ok := revertedBy[reverted.Hash]; ok {
+					sLogger.Infof("commit %s reverts the revert %s, restoring commit %s", revert.Hash, reverted.Hash, original)
+					dropped[original] = false
+					dropped[revert.Hash] = true
+				}
+			}
+		}
+	}
+
+	remaining := []gitCommit{}
+	for _, commit := range commits {
+		if !dropped[commit.Hash] {
+			remaining = append(remaining, commit)
+		}
+	}
+
+	return remaining
+}
+
+// resolveConventionalCommits finds the conventional commits since the last change to the changelog file,
+// where the contributors of each commit are only looked up when they are credited
+func resolveConventionalCommits(git gitCli, changelogFile string, depth int, filter commitFilter, withContributors bool) (*conventionalCommitChanges, error) {
 	var commits []gitCommit
 	if depth > 0 {
 		var err error
-		commits, err = git.listCommits(fmt.Sprintf("HEAD~%d..HEAD", depth))
+		commits, err = git.listCommits(filter.logArgs(fmt.Sprintf("HEAD~%d..HEAD", depth))...)
 		if err != nil {
 			sLogger.Errorf("could not list the commits between HEAD and HEAD~%d", depth)
 			sLogger.Fatal(err.Error())
@@ -116,7 +455,7 @@ func resolveConventionalCommits(git gitCli, changelogFile string, depth int) (*s
 			sLogger.Fatal(err.Error())
 		}
 
-		commits, err = git.listCommits(*cLogCommit + "..HEAD")
+		commits, err = git.listCommits(filter.logArgs(*cLogCommit + "..HEAD")...)
 		if err != nil {
 			sLogger.Errorf("could not list the commits between HEAD and %s", *cLogCommit)
 			sLogger.Fatal(err.Error())
@@ -125,15 +464,26 @@ func resolveConventionalCommits(git gitCli, changelogFile string, depth int) (*s
 
 	lastCommit, err := git.getLastModifiedCommit(changelogFile)
 	if err != nil {
-		return nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 
-	selfCommit, err := git.listCommits("-n 1 " + *lastCommit)
-	if err != nil {
-		return nil, nil, nil, nil, nil, err
+	// The last change to the changelog file is included, eg. for a commit adding the unreleased
+	// version to the changelog along with its changes
+	if *lastCommit != "" {
+		selfCommit, err := git.listCommits(filter.logArgs("-n", "1", *lastCommit)...)
+		if err != nil {
+			return nil, err
+		}
+
+		// With paths set, the log returns the latest commit touching them, which may not be the
+		// last change to the changelog file
+		for _, commit := range selfCommit {
+			if commit.Hash == *lastCommit {
+				commits = append(commits, commit)
+			}
+		}
 	}
 
-	commits = append(commits, selfCommit...)
 	uniqueCommits := []gitCommit{}
 
 	uniqueHashes := []string{}
@@ -156,44 +506,102 @@ func resolveConventionalCommits(git gitCli, changelogFile string, depth int) (*s
 		uniqueCommits = append(uniqueCommits, commit)
 	}
 
+	uniqueCommits = filter.filterCommits(dropRevertedCommits(uniqueCommits))
+
 	var commitMessages []string
 	for _, commit := range uniqueCommits {
-		commitMessages = append(commitMessages, commit.Message)
+		commitMessages = append(commitMessages, commit.fullMessage())
 	}
 
-	increment, fixedUnique, addedUnique, changedUnique, removedUnique := getUniqueConventionalCommitMessages(commitMessages, uniqueCommits, git)
-	return increment, fixedUnique, addedUnique, changedUnique, removedUnique, nil
+	changes := getUniqueConventionalCommitMessages(commitMessages, uniqueCommits, filter, withContributors, git)
+	return &changes, nil
 }
 
-func getUniqueConventionalCommitMessages(commitMessages []string, uniqueCommits []gitCommit, git gitCli) (*string, map[string]string, map[string]string, map[string]string, map[string]string) {
+func getUniqueConventionalCommitMessages(commitMessages []string, uniqueCommits []gitCommit, filter commitFilter, withContributors bool, git gitCli) conventionalCommitChanges {
 	increment, mappedTypes := parseConventionalCommitMessages(commitMessages...)
 
-	fixedUnique := map[string]string{}
-	addedUnique := map[string]string{}
-	changedUnique := map[string]string{}
-	removedUnique := map[string]string{}
-	for idx, ccType := range mappedTypes {
-		commit := uniqueCommits[idx]
+	changes := conventionalCommitChanges{
+		Increment: increment,
+		Files:     map[changeType]map[string]string{},
+		Breaking:  []string{},
+		Commits:   []conventionalCommitEntry{},
 
-		diff, err := git.getRefChanges(commit.Hash)
-		if err != nil {
-			sLogger.Warnf("failed to read changes for commit %s, changes will not be recorded in changelog", commit.Hash)
+		FileCommits:  map[changeType]map[string][]string{},
+		Contributors: map[string][]gitContributor{},
+	}
+	for idx, commit := range uniqueCommits {
+		ccType, ok := mappedTypes[idx]
+		if !ok {
+			continue
 		}
 
-		switch ccType {
-		case conventionalCommitFix:
-			setUnique(diff.Changed, commit.Message, fixedUnique)
-			fallthrough
-		default:
-			setUnique(diff.Added, commit.Message, addedUnique)
-			if ccType != conventionalCommitFix {
-				setUnique(diff.Changed, commit.Message, changedUnique)
+		ccMessage := parseConventionalCommit(commitMessages[idx])
+
+		if !excludedCommitTypes[ccType] {
+			diff, err := git.getRefChanges(commit.Hash, filter.Paths...)
+			if err != nil {
+				sLogger.Warnf("failed to read changes for commit %s, changes will not be recorded in changelog", commit.Hash)
+				diff = &gitDiff{}
+			}
+
+			commitEntry := conventionalCommitEntry{
+				Hash:        commit.Hash,
+				Type:        ccType,
+				Section:     changeChanged,
+				Description: commitHeader(commit.Message),
+			}
+			if withContributors {
+				commitEntry.Contributors = resolveCommitContributors(commit, git)
+				changes.Contributors[commit.Hash] = commitEntry.Contributors
+			}
+			if ccMessage != nil {
+				commitEntry.Description = ccMessage.Description
+				if ccMessage.Scope != nil {
+					commitEntry.Scope = *ccMessage.Scope
+				}
+			}
+			commitEntry.Files = append(append(append(commitEntry.Files, diff.Added...), diff.Changed...), diff.Removed...)
+			sort.Strings(commitEntry.Files)
+
+			if section, ok := commitTypeSections[ccType]; ok {
+				changes.setFiles(section, commitEntry.Files, commit)
+				commitEntry.Section = section
+			} else {
+				switch ccType {
+				case conventionalCommitFix:
+					changes.setFiles(changeFixed, diff.Changed, commit)
+					commitEntry.Section = changeFixed
+				case conventionalCommitFeat:
+					changes.setFiles(changeChanged, diff.Changed, commit)
+					commitEntry.Section = changeAdded
+				default:
+					changes.setFiles(changeChanged, diff.Changed, commit)
+				}
+				changes.setFiles(changeAdded, diff.Added, commit)
+				changes.setFiles(changeRemoved, diff.Removed, commit)
+			}
+
+			changes.Commits = append(changes.Commits, commitEntry)
+		} else {
+			sLogger.Debugf("commit %s is of excluded type %s, it will not be recorded in changelog", commit.Hash, ccType)
+		}
+
+		for _, breakingChange := range getBreakingChanges(ccMessage) {
+			isDuplicate := false
+			for _, existing := range changes.Breaking {
+				if existing == breakingChange {
+					isDuplicate = true
+					break
+				}
+			}
+
+			if !isDuplicate {
+				changes.Breaking = append(changes.Breaking, breakingChange)
 			}
-			setUnique(diff.Removed, commit.Message, removedUnique)
 		}
 	}
 
-	return increment, fixedUnique, addedUnique, changedUnique, removedUnique
+	return changes
 }
 
 func setUnique(entries []string, message string, uniqueMap map[string]string) {
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+	"gopkg.in/yaml.v3"
+)
+
+var entryMarkerRegex = regexp.MustCompile(`^([-*+]|\d+[.)]) +`)
+
+type exportedChangelog struct {
+	Unreleased *exportedRelease  `json:"unreleased,omitempty" yaml:"unreleased,omitempty"`
+	Releases   []exportedRelease `json:"releases" yaml:"releases"`
+}
+
+type exportedRelease struct {
+	Version     string            `json:"version,omitempty" yaml:"version,omitempty"`
+	Date        string            `json:"date,omitempty" yaml:"date,omitempty"`
+	Yanked      bool              `json:"yanked" yaml:"yanked"`
+	Increment   string            `json:"increment,omitempty" yaml:"increment,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Sections    []exportedSection `json:"sections" yaml:"sections"`
+}
+
+type exportedSection struct {
+	Type    string   `json:"type" yaml:"type"`
+	Heading string   `json:"heading" yaml:"heading"`
+	Entries []string `json:"entries" yaml:"entries"`
+}
+
+// exportRelease converts a parsed change to its structured form, with entries stripped of
+// their list markers
+func exportRelease(release *change) exportedRelease {
+	exported := exportedRelease{
+		Date:        release.Date,
+		Yanked:      release.Yanked,
+		Description: strings.TrimSpace(strings.Join(release.Description, "\n")),
+		Sections:    []exportedSection{},
+	}
+	if release.Version != nil {
+		exported.Version = release.Version.String()
+	}
+
+	for _, section := range changeSections {
+		entries := *release.sectionEntries(section.Type)
+		if len(entries) == 0 {
+			continue
+		}
+
+		exportedEntries := []string{}
+		for _, entry := range entries {
+			exportedEntries = append(exportedEntries, entryMarkerRegex.ReplaceAllString(strings.TrimSpace(entry), ""))
+		}
+
+		exported.Sections = append(exported.Sections, exportedSection{
+			Type:    string(section.Type),
+			Heading: section.Heading,
+			Entries: exportedEntries,
+		})
+	}
+
+	return exported
+}
+
+func exportChangelog(unreleased *change, increment *string, released []*change) exportedChangelog {
+	exported := exportedChangelog{
+		Releases: []exportedRelease{},
+	}
+
+	if unreleased != nil {
+		exportedUnreleased := exportRelease(unreleased)
+		if increment != nil {
+			exportedUnreleased.Increment = *increment
+
+			pending := change{}
+			if latest := getLatestRelease(released); latest != nil {
+				latestVersion := *latest.Version
+				pending.Version = &latestVersion
+			} else {
+				defaultVersion := semver.MustParse("0.0.0")
+				pending.Version = &defaultVersion
+			}
+			updateUnreleasedVersion(&pending, increment)
+			skipYankedVersions(&pending, increment, released)
+			exportedUnreleased.Version = pending.Version.String()
+		}
+
+		exported.Unreleased = &exportedUnreleased
+	}
+
+	for _, release := range released {
+		exported.Releases = append(exported.Releases, exportRelease(release))
+	}
+
+	return exported
+}
+
+func export() {
+	var options ExportOptions
+	parseOptions(&options)
+
+	_, unreleased, increment, released, err := parseChangelog(options.ChangelogFile)
+	if err != nil {
+		sLogger.Error("failed to parse the changelog file")
+		sLogger.Fatal(err.Error())
+	}
+
+	exported := exportChangelog(unreleased, increment, released)
+
+	var data []byte
+	switch strings.ToLower(options.Format) {
+	case "json":
+		data, err = json.MarshalIndent(exported, "", "  ")
+		data = append(data, '\n')
+	case "yaml", "yml":
+		data, err = yaml.Marshal(exported)
+	default:
+		err = fmt.Errorf("export format %s is not supported, only json and yaml are supported", options.Format)
+	}
+	if err != nil {
+		sLogger.Error("failed to export the changelog")
+		sLogger.Fatal(err.Error())
+	}
+
+	if options.Output == "" {
+		fmt.Print(string(data))
+		return
+	}
+
+	if err := os.WriteFile(options.Output, data, 0644); err != nil {
+		sLogger.Errorf("failed to write the exported changelog to %s", options.Output)
+		sLogger.Fatal(err.Error())
+	}
+}
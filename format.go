@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var bulletMarkerRegex = regexp.MustCompile(`^[*+]( +)`)
+
+// formatChangelog renders the changelog in its canonical form, keeping the header before the first
+// release, and anything after the last release, such as link reference definitions, as written
+func formatChangelog(changelog []byte, unreleased *change, increment *string, released []*change) string {
+	sections := []*change{}
+	if unreleased != nil {
+		sections = append(sections, unreleased)
+	}
+	sortedReleased := append([]*change{}, released...)
+	sort.SliceStable(sortedReleased, func(i, j int) bool {
+		return sortedReleased[i].Version.GT(*sortedReleased[j].Version)
+	})
+	sections = append(sections, sortedReleased...)
+
+	headerEnd := contentEnd(changelog)
+	footerStart := 0
+	for _, section := range sections {
+		if section.Span == nil {
+			continue
+		}
+		if section.Span.Start < headerEnd {
+			headerEnd = section.Span.Start
+		}
+		if section.Span.End > footerStart {
+			footerStart = section.Span.End
+		}
+	}
+	if len(sections) == 0 {
+		footerStart = headerEnd
+	}
+
+	sb := strings.Builder{}
+	if header := strings.TrimRight(string(changelog[:headerEnd]), "\n"); header != "" {
+		sb.WriteString(header)
+		sb.WriteString("\n\n")
+	}
+
+	for idx, section := range sections {
+		if idx > 0 {
+			sb.WriteString("\n")
+		}
+
+		// A section heading directly follows the description, as it directly follows the entries
+		formatted := change{
+			Description: append([]string{}, section.Description...),
+		}
+		if last := len(formatted.Description) - 1; last >= 0 {
+			formatted.Description[last] = strings.TrimRight(formatted.Description[last], "\n")
+		}
+		for _, changeSection := range changeSections {
+			entries := formatted.sectionEntries(changeSection.Type)
+			for _, entry := range *section.sectionEntries(changeSection.Type) {
+				*entries = append(*entries, bulletMarkerRegex.ReplaceAllString(strings.TrimRight(entry, "\n"), linePrefix))
+			}
+		}
+
+		if section == unreleased {
+			if increment != nil {
+				formatted.renderChangeText(*increment)
+			} else {
+				formatted.renderChangeText()
+			}
+			sb.WriteString(*formatted.VersionText)
+		} else {
+			formatted.renderChangeText()
+			sb.WriteString(releaseHeading(section))
+		}
+
+		if *formatted.Text != "" {
+			sb.WriteString(*formatted.Text)
+			sb.WriteString("\n")
+		}
+	}
+
+	if footer := strings.Trim(string(changelog[footerStart:]), "\n"); footer != "" {
+		sb.WriteString("\n")
+		sb.WriteString(footer)
+		sb.WriteString("\n")
+	}
+
+	return sb.String()
+}
+
+func formatChangelogFile() {
+	var options FormatOptions
+	parseOptions(&options)
+
+	changelog, unreleased, increment, released, err := parseChangelog(options.ChangelogFile)
+	if err != nil {
+		sLogger.Fatal(err.Error())
+	}
+
+	formatted := formatChangelog(changelog, unreleased, increment, released)
+
+	if !options.Check {
+		if err := os.WriteFile(options.ChangelogFile, []byte(formatted), 0644); err != nil {
+			sLogger.Errorf("failed to write to changelog file %s", options.ChangelogFile)
+			sLogger.Fatal(err.Error())
+		}
+		return
+	}
+
+	if formatted == string(changelog) {
+		return
+	}
+
+	diff, err := diffFormattedChangelog(options.ChangelogFile, formatted)
+	if err != nil {
+		sLogger.Error("failed to diff the changelog file against the formatted changelog")
+		sLogger.Fatal(err.Error())
+	}
+
+	fmt.Println(*diff)
+	os.Exit(parseError)
+}
+
+// diffFormattedChangelog diffs the changelog file against its formatted form, which is written to a
+// temporary directory that is removed once the diff is done
+func diffFormattedChangelog(changelogFile, formatted string) (*string, error) {
+	tempDir, err := os.MkdirTemp("", "changehelper")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tempDir)
+
+	formattedFile := filepath.Join(tempDir, filepath.Base(changelogFile))
+	if err := os.WriteFile(formattedFile, []byte(formatted), 0644); err != nil {
+		return nil, err
+	}
+
+	absChangelogFile, err := filepath.Abs(changelogFile)
+	if err != nil {
+		return nil, err
+	}
+
+	diff, _, err := runCommand(tempDir, gitCmd, "diff", "--no-index", "--no-color", absChangelogFile, formattedFile)
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
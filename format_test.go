@@ -0,0 +1,45 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func formatTestChangelog(t *testing.T, changelog string) string {
+	t.Helper()
+
+	if err := setupLogger(0); err != nil {
+		t.Fatal(err)
+	}
+
+	changelogFile := filepath.Join(t.TempDir(), "CHANGELOG.md")
+	if err := os.WriteFile(changelogFile, []byte(changelog), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	source, unreleased, increment, released, err := parseChangelog(changelogFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return formatChangelog(source, unreleased, increment, released)
+}
+
+func TestFormatChangelogAfterLooseListItem(t *testing.T) {
+	changelog := "# Changelog\n\n## [1.0.0] - 2024-01-01\n\n### Added\n\n- a\n\n- b\n\n### Fixed\n\n- c\n"
+	expected := "# Changelog\n\n## [1.0.0] - 2024-01-01\n### Added\n- a\n- b\n### Fixed\n- c\n"
+
+	if formatted := formatTestChangelog(t, changelog); formatted != expected {
+		t.Errorf("expected the changelog to be formatted as:\n%s\nbut was:\n%s", expected, formatted)
+	}
+}
+
+func TestFormatChangelogAfterProse(t *testing.T) {
+	changelog := "# Changelog\n\n## [1.0.0] - 2024-01-01\n\nSome description.\n\n### Fixed\n\n- c\n"
+	expected := "# Changelog\n\n## [1.0.0] - 2024-01-01\nSome description.\n### Fixed\n- c\n"
+
+	if formatted := formatTestChangelog(t, changelog); formatted != expected {
+		t.Errorf("expected the changelog to be formatted as:\n%s\nbut was:\n%s", expected, formatted)
+	}
+}
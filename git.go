@@ -58,6 +58,23 @@ func (git gitCli) getRemote() (*string, error) {
 	return &remoteString, nil
 }
 
+func (git gitCli) getRemoteURL(remote string) (*string, error) {
+	sLogger.Debugf("looking up url for git remote %s", remote)
+	remoteURL, code, err := runCommand(git.WorkingDirectory, gitCmd, "remote", "get-url", remote)
+	if err != nil {
+		sLogger.Errorf("failed to lookup url for git remote %s", remote)
+		return nil, err
+	}
+	if code != 0 {
+		return nil, nonZeroCode("remote get-url")
+	}
+	if remoteURL == nil || *remoteURL == "" {
+		return nil, fmt.Errorf("failed to find a url for git remote %s", remote)
+	}
+
+	return remoteURL, nil
+}
+
 func (git gitCli) checkout(ref string) error {
 	sLogger.Debug("looking up git remotes")
 	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, "checkout", ref)
@@ -89,8 +106,21 @@ func (git gitCli) pull() error {
 }
 
 type gitCommit struct {
-	Hash    string
-	Message string
+	Hash        string
+	AuthorName  string
+	AuthorEmail string
+	Message     string
+	Body        string
+}
+
+// fullMessage is the commit message including its body and trailers, as used when parsing
+// conventional commits
+func (c gitCommit) fullMessage() string {
+	if c.Body == "" {
+		return c.Message
+	}
+
+	return c.Message + "\n\n" + c.Body
 }
 
 func (git gitCli) listTags(prefix string) ([]string, error) {
@@ -123,9 +153,14 @@ func (git gitCli) listTags(prefix string) ([]string, error) {
 	return tags, nil
 }
 
+const (
+	gitFieldSeparator  = "\x1f"
+	gitRecordSeparator = "\x1e"
+)
+
 func (git gitCli) listCommits(commitRange ...string) ([]gitCommit, error) {
 	sLogger.Debug("looking up git commits")
-	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, append([]string{"log", `--pretty=format:"%H %s"`}, commitRange...)...)
+	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, append([]string{"log", "--pretty=format:%H%x1f%aN%x1f%aE%x1f%s%x1f%b%x1e"}, commitRange...)...)
 	if err != nil {
 		sLogger.Error("failed to run git log")
 		return nil, err
@@ -135,25 +170,100 @@ func (git gitCli) listCommits(commitRange ...string) ([]gitCommit, error) {
 	}
 
 	gitCommits := []gitCommit{}
-	commitLines := strings.Split(*stdOut, "\n")
-	for _, commitLine := range commitLines {
-		sLogger.Debugf("processing commit: %s", commitLine)
-		if commitLine != "" && commitLine != "\"\"" {
-			tidyCommitLine := commitLine[1 : len(commitLine)-1]
-			splitLine := strings.SplitN(tidyCommitLine, " ", 2)
-			gitCommits = append(gitCommits, gitCommit{
-				Hash:    splitLine[0],
-				Message: splitLine[1],
-			})
+	for _, commitRecord := range strings.Split(*stdOut, gitRecordSeparator) {
+		commitRecord = strings.TrimLeft(commitRecord, "\n")
+		sLogger.Debugf("processing commit: %s", commitRecord)
+		if commitRecord == "" {
+			continue
 		}
+
+		commitFields := strings.SplitN(commitRecord, gitFieldSeparator, 5)
+		if len(commitFields) < 5 {
+			sLogger.Warnf("failed to read the fields of commit record: %s", commitRecord)
+			continue
+		}
+
+		gitCommits = append(gitCommits, gitCommit{
+			Hash:        commitFields[0],
+			AuthorName:  commitFields[1],
+			AuthorEmail: commitFields[2],
+			Message:     commitFields[3],
+			Body:        strings.TrimSpace(commitFields[4]),
+		})
 	}
 
 	return gitCommits, nil
 }
 
-func (git gitCli) getRefChanges(ref string) (*gitDiff, error) {
+// checkMailmap maps each contact, in the form 'Name <email>', to its canonical form from the mailmap
+func (git gitCli) checkMailmap(contacts ...string) ([]string, error) {
+	sLogger.Debugf("looking up contacts %v in the mailmap", contacts)
+	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, append([]string{"check-mailmap"}, contacts...)...)
+	if err != nil {
+		sLogger.Error("failed to run git check-mailmap")
+		return nil, err
+	}
+	if code != 0 {
+		return nil, nonZeroCode("check-mailmap")
+	}
+	if stdOut == nil {
+		return nil, errors.New("git check-mailmap returned no contacts")
+	}
+
+	return strings.Split(strings.TrimSpace(*stdOut), "\n"), nil
+}
+
+// getWorkdirPrefix finds the path of the working directory relative to the root of the repository,
+// which is empty at the root
+func (git gitCli) getWorkdirPrefix() (*string, error) {
+	sLogger.Debug("looking up the working directory prefix")
+	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, "rev-parse", "--show-prefix")
+	if err != nil {
+		sLogger.Error("failed to lookup the working directory prefix")
+		return nil, err
+	}
+	if code != 0 {
+		return nil, nonZeroCode("rev-parse")
+	}
+
+	prefix := ""
+	if stdOut != nil {
+		prefix = strings.TrimSpace(*stdOut)
+	}
+
+	return &prefix, nil
+}
+
+// getHooksPath finds the directory git runs hooks from, taking core.hooksPath into account
+func (git gitCli) getHooksPath() (*string, error) {
+	sLogger.Debug("looking up git hooks path")
+	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		sLogger.Error("failed to lookup git hooks path")
+		return nil, err
+	}
+	if code != 0 {
+		return nil, nonZeroCode("rev-parse")
+	}
+	if stdOut == nil || *stdOut == "" {
+		return nil, errors.New("failed to find the git hooks path")
+	}
+
+	hooksPath := *stdOut
+	if !filepath.IsAbs(hooksPath) {
+		hooksPath = filepath.Join(git.WorkingDirectory, hooksPath)
+	}
+
+	return &hooksPath, nil
+}
+
+func (git gitCli) getRefChanges(ref string, paths ...string) (*gitDiff, error) {
 	sLogger.Debugf("looking up changes for ref %s", ref)
-	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, "show", "--name-status", ref, "--pretty=format:")
+	showArgs := []string{"show", "--name-status", ref, "--pretty=format:"}
+	if len(paths) > 0 {
+		showArgs = append(append(showArgs, "--"), paths...)
+	}
+	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, showArgs...)
 	if err != nil {
 		sLogger.Errorf("git show for %s failed", ref)
 		return nil, err
@@ -166,6 +276,25 @@ func (git gitCli) getRefChanges(ref string) (*gitDiff, error) {
 	return &diff, nil
 }
 
+func (git gitCli) getRefDate(ref string) (*string, error) {
+	sLogger.Debugf("looking up the commit date for ref %s", ref)
+	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, "log", "-n", "1", "--date=short", "--pretty=format:%cd", ref, "--")
+	if err != nil {
+		sLogger.Errorf("failed to run git log for %s", ref)
+		return nil, err
+	}
+	if code != 0 {
+		return nil, nonZeroCode("log")
+	}
+
+	date := strings.TrimSpace(*stdOut)
+	if date == "" {
+		return nil, fmt.Errorf("failed to find a commit date for ref %s", ref)
+	}
+
+	return &date, nil
+}
+
 func (git gitCli) getCurrentBranch() (*string, error) {
 	sLogger.Debug("getting the current branch")
 	stdOut, code, err := runCommand(git.WorkingDirectory, gitCmd, "rev-parse", "--abbrev-ref", "HEAD")
@@ -463,3 +592,37 @@ func (git gitCli) resetTag(remote, tag string) error {
 
 	return nil
 }
+
+func (git gitCli) deleteBranch(remote, branch string) error {
+	sLogger.Debugf("attempting to delete branch %s from %s", branch, remote)
+
+	_, code, err := runCommand(git.WorkingDirectory, gitCmd, "push", remote, "--delete", branch)
+	if err != nil {
+		sLogger.Error("failed to run git push")
+		return err
+	}
+	if code != 0 {
+		return nonZeroCode("push delete")
+	}
+
+	return nil
+}
+
+func (git gitCli) deleteTag(remote, tag string) error {
+	sLogger.Debugf("attempting to delete tag %s from %s", tag, remote)
+
+	if _, _, err := runCommand(git.WorkingDirectory, gitCmd, "tag", "-d", tag); err != nil {
+		sLogger.Warn("failed to remove local tag, continuing anyway")
+	}
+
+	_, code, err := runCommand(git.WorkingDirectory, gitCmd, "push", remote, "--delete", "refs/tags/"+tag)
+	if err != nil {
+		sLogger.Error("failed to run git push")
+		return err
+	}
+	if code != 0 {
+		return nonZeroCode("push delete")
+	}
+
+	return nil
+}
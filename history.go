@@ -0,0 +1,191 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+// historyRelease is a release found in git, along with the ref it was released from
+type historyRelease struct {
+	Version semver.Version
+	Ref     string
+}
+
+// listHistoryReleases resolves the release refs in git for every released version, in ascending
+// order, skipping any version that only exists as a major or minor ref
+func listHistoryReleases(options InitOptions, git gitCli) ([]historyRelease, error) {
+	versions, err := listReleasedVersionFromGit(options.UseTags, git, options.GitPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	refPrefix := ""
+	if !options.UseTags {
+		if err := git.fetch(); err != nil {
+			sLogger.Warn("failed to fetch from git, release branches may be out of date")
+		}
+		refPrefix = getRemote(git) + "/"
+	}
+
+	sort.Slice(versions, func(i, j int) bool {
+		return versions[i].LT(versions[j])
+	})
+
+	releases := []historyRelease{}
+	for _, version := range versions {
+		if len(releases) > 0 && releases[len(releases)-1].Version.EQ(version) {
+			continue
+		}
+
+		ref := refPrefix + releaseRefName(options.GitPrefix, options.VersionPrefix, version)
+		if _, err := git.getRefDate(ref); err != nil {
+			sLogger.Debugf("skipping version %s as there is no release ref %s", version.String(), ref)
+			continue
+		}
+
+		releases = append(releases, historyRelease{
+			Version: version,
+			Ref:     ref,
+		})
+	}
+
+	return releases, nil
+}
+
+// loadHistoryCommitsToChange records the conventional commits in the range under the change,
+// returning the increment they resolve to, or nil if none of the commits are conventional
+func loadHistoryCommitsToChange(options InitOptions, commitRange string, change *change, git gitCli) (*string, error) {
+	commits, err := git.listCommits(commitRange)
+	if err != nil {
+		return nil, err
+	}
+
+	conventionalCommits := []gitCommit{}
+	commitMessages := []string{}
+	for _, commit := range dropRevertedCommits(commits) {
+		if isConventionalCommit(commit.fullMessage()) {
+			conventionalCommits = append(conventionalCommits, commit)
+			commitMessages = append(commitMessages, commit.fullMessage())
+		}
+	}
+
+	if len(conventionalCommits) == 0 {
+		sLogger.Warnf("no conventional commits were found in %s", commitRange)
+		return nil, nil
+	}
+
+	changes := getUniqueConventionalCommitMessages(commitMessages, conventionalCommits, commitFilter{}, options.CommitAuthors || options.Contributors, git)
+	if err := addConventionalCommitEntries(options.GitWorkingDirectory, options.ChangelogFile, false, options.EntryStyleOptions, change, changes); err != nil {
+		return nil, err
+	}
+
+	return changes.Increment, nil
+}
+
+// buildChangelogFromHistory renders a full changelog from the release refs in git, where each
+// release holds the conventional commits since the release before it
+func buildChangelogFromHistory(options InitOptions, git gitCli) (string, error) {
+	releases, err := listHistoryReleases(options, git)
+	if err != nil {
+		return "", err
+	}
+
+	sb := strings.Builder{}
+	sb.WriteString(changelogHeader)
+
+	unreleased := change{}
+	previousRef := ""
+	if len(releases) > 0 {
+		previousRef = releases[len(releases)-1].Ref
+	}
+
+	unreleasedRange := "HEAD"
+	if previousRef != "" {
+		unreleasedRange = previousRef + "..HEAD"
+	}
+
+	increment, err := loadHistoryCommitsToChange(options, unreleasedRange, &unreleased, git)
+	if err != nil {
+		return "", err
+	}
+	if increment != nil && unreleased.hasEntries() {
+		unreleased.renderChangeText(*increment)
+		sb.WriteString(*unreleased.VersionText)
+		sb.WriteString(*unreleased.Text)
+		sb.WriteString("\n\n")
+	}
+
+	for idx := len(releases) - 1; idx >= 0; idx-- {
+		release := releases[idx]
+
+		date, err := git.getRefDate(release.Ref)
+		if err != nil {
+			return "", err
+		}
+
+		commitRange := release.Ref
+		if idx > 0 {
+			commitRange = releases[idx-1].Ref + ".." + release.Ref
+		}
+
+		version := release.Version
+		released := change{
+			Version: &version,
+			Date:    *date,
+		}
+		if _, err := loadHistoryCommitsToChange(options, commitRange, &released, git); err != nil {
+			return "", err
+		}
+
+		sb.WriteString(releaseHeading(&released))
+		if released.hasEntries() {
+			released.renderChangeText()
+			sb.WriteString(*released.Text)
+			sb.WriteString("\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	return strings.TrimSuffix(sb.String(), "\n"), nil
+}
+
+func initChangelog() {
+	var options InitOptions
+	parseOptions(&options)
+
+	git := gitCli{
+		WorkingDirectory: options.GitWorkingDirectory,
+	}
+
+	if _, err := os.Stat(options.ChangelogFile); err == nil {
+		if !options.Force {
+			sLogger.Fatalf("changelog file %s already exists", options.ChangelogFile)
+		}
+		sLogger.Warnf("changelog file %s already exists, going to replace it", options.ChangelogFile)
+	} else if !errors.Is(err, os.ErrNotExist) {
+		sLogger.Errorf("failed to read changelog file %s", options.ChangelogFile)
+		sLogger.Fatal(err.Error())
+	}
+
+	contents := changelogHeader
+	if options.FromHistory {
+		var err error
+		contents, err = buildChangelogFromHistory(options, git)
+		if err != nil {
+			sLogger.Error("failed to build the changelog from the git history")
+			sLogger.Fatal(err.Error())
+		}
+	}
+
+	if err := os.WriteFile(options.ChangelogFile, []byte(contents), 0644); err != nil {
+		sLogger.Errorf("failed to write to changelog file %s", options.ChangelogFile)
+		sLogger.Fatal(err.Error())
+	}
+
+	fmt.Printf("created changelog file %s\n", options.ChangelogFile)
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/blang/semver"
+)
+
+const unreleasedLinkLabel = "Unreleased"
+
+var linkDefinitionRegex = regexp.MustCompile(`^\s{0,3}\[([^\]]+)\]:\s*(\S+)`)
+
+var codeFenceRegex = regexp.MustCompile("^\\s{0,3}(`{3,}|~{3,})")
+
+var (
+	scpRemoteRegex    = regexp.MustCompile(`^[\w.-]+@([\w.-]+):(.*)$`)
+	remoteSchemeRegex = regexp.MustCompile(`^(ssh|git|git\+ssh|http|https)://`)
+	remoteUserRegex   = regexp.MustCompile(`^https://[^@/]+@`)
+	remotePortRegex   = regexp.MustCompile(`^https://([^/:]+):\d+/`)
+)
+
+type linkDefinition struct {
+	Label string
+	URL   string
+	Start int
+	End   int
+}
+
+// parseLinkDefinitions finds the link reference definitions in the changelog, skipping any lines in
+// fenced code blocks, which are only examples
+func parseLinkDefinitions(changelog []byte) []linkDefinition {
+	definitions := []linkDefinition{}
+
+	openFence := ""
+	pos := 0
+	for pos < len(changelog) {
+		end := lineEnd(changelog, pos+1)
+		line := string(changelog[pos:end])
+
+		// A fence is closed by a fence of the same character, at least as long as the one opening it
+		if fence := codeFenceRegex.FindStringSubmatch(line); fence != nil {
+			if openFence == "" {
+				openFence = fence[1]
+			} else if fence[1][0] == openFence[0] && len(fence[1]) >= len(openFence) && strings.TrimSpace(line) == fence[1] {
+				openFence = ""
+			}
+			pos = end
+			continue
+		}
+		if openFence != "" {
+			pos = end
+			continue
+		}
+
+		if matches := linkDefinitionRegex.FindStringSubmatch(line); matches != nil {
+			definitions = append(definitions, linkDefinition{
+				Label: matches[1],
+				URL:   matches[2],
+				Start: pos,
+				End:   end,
+			})
+		}
+		pos = end
+	}
+
+	return definitions
+}
+
+// hasCompareLinks checks if the changelog already has link definitions for the unreleased changes, or
+// for a version, so that they are kept up to date by default
+func hasCompareLinks(changelog []byte) bool {
+	for _, definition := range parseLinkDefinitions(changelog) {
+		if strings.EqualFold(definition.Label, unreleasedLinkLabel) {
+			return true
+		}
+		if _, err := semver.Parse(definition.Label); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// remoteToWebURL converts a git remote, eg. git@github.com:org/repo.git, to the https url of the repository
+func remoteToWebURL(remote string) string {
+	webURL := strings.TrimSpace(remote)
+
+	if scpRemoteRegex.MatchString(webURL) {
+		webURL = scpRemoteRegex.ReplaceAllString(webURL, "https://$1/$2")
+	}
+
+	webURL = remoteSchemeRegex.ReplaceAllString(webURL, "https://")
+	webURL = remoteUserRegex.ReplaceAllString(webURL, "https://")
+	webURL = remotePortRegex.ReplaceAllString(webURL, "https://$1/")
+
+	return strings.TrimSuffix(strings.TrimSuffix(webURL, "/"), ".git")
+}
+
+func resolveCompareURL(compareURL string, git gitCli) (string, error) {
+	if compareURL != "" {
+		return strings.TrimSuffix(compareURL, "/"), nil
+	}
+
+	remoteURL, err := git.getRemoteURL(getRemote(git))
+	if err != nil {
+		return "", err
+	}
+
+	return remoteToWebURL(*remoteURL), nil
+}
+
+func releaseRefName(gitPrefix, versionPrefix string, version semver.Version) string {
+	return fmt.Sprintf("%s/%s%s", gitPrefix, versionPrefix, version.String())
+}
+
+// updateCompareLinks adds or refreshes the link definitions for the unreleased changes, and the
+// newly released version, comparing it against the release preceding it
+func updateCompareLinks(changelogFile, baseURL, gitPrefix, versionPrefix string, version semver.Version, releases []*change) error {
+	changelog, err := readChangelogFile(changelogFile)
+	if err != nil {
+		return err
+	}
+
+	// Yanked releases are skipped, as their refs may have been deleted when they were yanked
+	var previous *semver.Version
+	for _, release := range releases {
+		if release.Version == nil || release.Yanked || !release.Version.LT(version) {
+			continue
+		}
+		if previous == nil || release.Version.GT(*previous) {
+			previous = release.Version
+		}
+	}
+
+	versionRef := releaseRefName(gitPrefix, versionPrefix, version)
+	versionURL := fmt.Sprintf("%s/releases/tag/%s", baseURL, versionRef)
+	if previous != nil {
+		versionURL = fmt.Sprintf("%s/compare/%s...%s", baseURL, releaseRefName(gitPrefix, versionPrefix, *previous), versionRef)
+	}
+	unreleasedURL := fmt.Sprintf("%s/compare/%s...HEAD", baseURL, versionRef)
+
+	definitions := parseLinkDefinitions(changelog)
+
+	var unreleasedDefinition, versionDefinition *linkDefinition
+	for idx, definition := range definitions {
+		switch {
+		case strings.EqualFold(definition.Label, unreleasedLinkLabel):
+			unreleasedDefinition = &definitions[idx]
+		case definition.Label == version.String():
+			versionDefinition = &definitions[idx]
+		}
+	}
+
+	unreleasedLink := fmt.Sprintf("[%s]: %s\n", unreleasedLinkLabel, unreleasedURL)
+	versionLink := fmt.Sprintf("[%s]: %s\n", version.String(), versionURL)
+
+	edits := []changelogEdit{}
+	switch {
+	case unreleasedDefinition != nil && versionDefinition != nil:
+		edits = append(edits,
+			changelogEdit{Start: unreleasedDefinition.Start, End: unreleasedDefinition.End, Text: unreleasedLink},
+			changelogEdit{Start: versionDefinition.Start, End: versionDefinition.End, Text: versionLink},
+		)
+	case unreleasedDefinition != nil:
+		edits = append(edits, changelogEdit{Start: unreleasedDefinition.Start, End: unreleasedDefinition.End, Text: unreleasedLink + versionLink})
+	case versionDefinition != nil:
+		edits = append(edits, changelogEdit{Start: versionDefinition.Start, End: versionDefinition.End, Text: unreleasedLink + versionLink})
+	case len(definitions) > 0:
+		edits = append(edits, changelogEdit{Start: definitions[0].Start, End: definitions[0].Start, Text: unreleasedLink + versionLink})
+	default:
+		edits = append(edits, changelogEdit{Start: len(changelog), End: len(changelog), Text: unreleasedLink + versionLink, Insert: true})
+	}
+
+	sLogger.Infof("updating compare links for version %s", version.String())
+
+	if err := os.WriteFile(changelogFile, applyChangelogEdits(changelog, edits), 0644); err != nil {
+		sLogger.Errorf("failed to write to changelog file %s", changelogFile)
+		return err
+	}
+
+	return nil
+}
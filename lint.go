@@ -0,0 +1,229 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/blang/semver"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+const (
+	lintMultipleUnreleased = "multiple-unreleased"
+	lintInvalidHeading     = "invalid-release-heading"
+	lintDuplicateVersion   = "duplicate-version"
+	lintVersionOrder       = "version-order"
+	lintDateOrder          = "date-order"
+	lintUnknownSection     = "unknown-section"
+	lintEmptySection       = "empty-section"
+)
+
+// almostReleasedRegex catches headings that look like they are meant to be a release, but that
+// don't match the released regex, eg. '## 1.2.3 - 2022-01-01', or '## [1.2] - 2022-01-01'
+var almostReleasedRegex = regexp.MustCompile(`^\[?v?\d+\.\d+`)
+
+type changelogDiagnostic struct {
+	Line    int
+	Rule    string
+	Message string
+}
+
+type changelogLinter struct {
+	Changelog   []byte
+	Diagnostics []changelogDiagnostic
+
+	unreleasedRegex          *regexp.Regexp
+	unreleasedIncrementRegex *regexp.Regexp
+	releasedRegex            *regexp.Regexp
+	versionRegex             *regexp.Regexp
+
+	unreleasedLine  int
+	versionLines    map[string]int
+	previousVersion *semver.Version
+	previousDate    *time.Time
+
+	inRelease       bool
+	sectionHeading  string
+	sectionLine     int
+	sectionHasBlock bool
+}
+
+func (l *changelogLinter) report(line int, rule, message string, args ...interface{}) {
+	l.Diagnostics = append(l.Diagnostics, changelogDiagnostic{
+		Line:    line,
+		Rule:    rule,
+		Message: fmt.Sprintf(message, args...),
+	})
+}
+
+func (l *changelogLinter) lineNumber(node ast.Node) int {
+	return strings.Count(string(l.Changelog[:lineStart(l.Changelog, nodeStart(node, l.Changelog))]), "\n") + 1
+}
+
+func (l *changelogLinter) closeSection() {
+	if l.sectionHeading != "" && !l.sectionHasBlock {
+		l.report(l.sectionLine, lintEmptySection, "section '%s' has no entries", l.sectionHeading)
+	}
+
+	l.sectionHeading = ""
+	l.sectionHasBlock = false
+}
+
+func (l *changelogLinter) startSection(heading string, line int) {
+	l.closeSection()
+	l.sectionHeading = heading
+	l.sectionLine = line
+}
+
+func (l *changelogLinter) checkReleaseHeading(heading, deformatted string, line int) bool {
+	if l.unreleasedRegex.MatchString(deformatted) || l.unreleasedIncrementRegex.MatchString(deformatted) {
+		if l.unreleasedLine > 0 {
+			l.report(line, lintMultipleUnreleased, "more than one unreleased section, the first is on line %d", l.unreleasedLine)
+		} else {
+			l.unreleasedLine = line
+		}
+		return true
+	}
+
+	if !l.releasedRegex.MatchString(deformatted) {
+		if almostReleasedRegex.MatchString(deformatted) || strings.Contains(deformatted, "unreleased") {
+			l.report(line, lintInvalidHeading, "heading '%s' looks like a release, but is not in the format '[x.y.z] - YYYY-MM-DD', '[x.y.z] - YYYY-MM-DD [YANKED]', or '[Unreleased]'", heading)
+			return true
+		}
+		return false
+	}
+
+	extractedVersion := l.versionRegex.FindAllString(heading, -1)
+	cleanVersion := strings.Trim(extractedVersion[0], "[]")
+	version, err := semver.Parse(cleanVersion)
+	if err != nil {
+		l.report(line, lintInvalidHeading, "version '%s' could not be parsed: %s", cleanVersion, err.Error())
+		return true
+	}
+
+	firstLine, isDuplicate := l.versionLines[version.String()]
+	if isDuplicate {
+		l.report(line, lintDuplicateVersion, "version %s is already released on line %d", version.String(), firstLine)
+	} else {
+		l.versionLines[version.String()] = line
+	}
+
+	// Yanked versions are skipped when calculating the next version, so can be followed by lower versions,
+	// and a duplicate is already reported, so isn't reported again for its order
+	if !strings.HasSuffix(deformatted, yankedSuffix) && !isDuplicate {
+		if l.previousVersion != nil && version.GTE(*l.previousVersion) {
+			l.report(line, lintVersionOrder, "version %s is listed below %s, but releases should be in descending order", version.String(), l.previousVersion.String())
+		}
+		l.previousVersion = &version
+	}
+
+	date, err := time.Parse(dateFormat, releaseDate(deformatted))
+	if err != nil {
+		l.report(line, lintInvalidHeading, "release date could not be parsed: %s", err.Error())
+		return true
+	}
+
+	if l.previousDate != nil && date.After(*l.previousDate) {
+		l.report(line, lintDateOrder, "version %s is dated %s, which is after the newer release dated %s", version.String(), date.Format(dateFormat), l.previousDate.Format(dateFormat))
+	}
+	l.previousDate = &date
+
+	return true
+}
+
+func (l *changelogLinter) checkNode(node ast.Node) {
+	heading, isHeading := node.(*ast.Heading)
+	if !isHeading {
+		if l.inRelease {
+			l.sectionHasBlock = true
+		}
+		return
+	}
+
+	headingText := nodeText(node, l.Changelog)
+	deformatted := strings.ReplaceAll(strings.ToLower(headingText), " ", "")
+	line := l.lineNumber(node)
+
+	if l.checkReleaseHeading(headingText, deformatted, line) {
+		l.inRelease = true
+		l.startSection(headingText, line)
+		return
+	}
+
+	if !l.inRelease || heading.Level <= 1 {
+		return
+	}
+
+	// A heading directly under the release heading is its content, while a change section needs its own
+	if findChangeSection(l.sectionHeading) == nil {
+		l.sectionHasBlock = true
+	}
+
+	if findChangeSection(headingText) != nil {
+		l.startSection(headingText, line)
+		return
+	}
+
+	l.closeSection()
+	l.report(line, lintUnknownSection, "section '%s' is not one of the supported sections", headingText)
+}
+
+func lintChangelogFile(changelogFile string) ([]changelogDiagnostic, error) {
+	changelog, err := readChangelogFile(changelogFile)
+	if err != nil {
+		return nil, err
+	}
+
+	unreleasedRegex, unreleasedIncrementRegex, releasedRegex, versionRegex, err := parsingRegexes()
+	if err != nil {
+		return nil, err
+	}
+
+	linter := changelogLinter{
+		Changelog:   changelog,
+		Diagnostics: []changelogDiagnostic{},
+
+		unreleasedRegex:          unreleasedRegex,
+		unreleasedIncrementRegex: unreleasedIncrementRegex,
+		releasedRegex:            releasedRegex,
+		versionRegex:             versionRegex,
+
+		versionLines: map[string]int{},
+	}
+
+	document := goldmark.DefaultParser().Parse(text.NewReader(changelog))
+	for node := document.FirstChild(); node != nil; node = node.NextSibling() {
+		if nodeStart(node, changelog) < 0 {
+			continue
+		}
+		linter.checkNode(node)
+	}
+	linter.closeSection()
+
+	sort.SliceStable(linter.Diagnostics, func(i, j int) bool {
+		return linter.Diagnostics[i].Line < linter.Diagnostics[j].Line
+	})
+
+	return linter.Diagnostics, nil
+}
+
+func lintChangelog(changelogFile string) {
+	diagnostics, err := lintChangelogFile(changelogFile)
+	if err != nil {
+		sLogger.Fatal(err.Error())
+	}
+
+	for _, diagnostic := range diagnostics {
+		fmt.Printf("%s:%d: %s: %s\n", changelogFile, diagnostic.Line, diagnostic.Rule, diagnostic.Message)
+	}
+
+	if len(diagnostics) > 0 {
+		os.Exit(parseError)
+	}
+}
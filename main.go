@@ -11,6 +11,7 @@ const operationsText = `Usage = changehelper [global options] <operation>
 
 Operations:
 
+init					Create a new changelog file, optionally from the release history in git
 new-version				Create a new in progress version interactively
 print-current-version			Print the current version in the changelog file
 print-unreleased-version		Print the unreleased version based on the changelog file, or conventional commit(s)
@@ -20,14 +21,25 @@ print-changes				Print any changes matching the input version
 update					Update the version in the changelog file
 release					Commit and push changes to git, ie changes to the changelog, and branches
 update-and-release			Run update, followed by release in order
+promote					Promote the pre-releases of a version to a final release, followed by release
 enforce-unreleased			Validate that there is a pending unreleased change
 enforce-conventional-commits		Enforce that all commits adhere to conventional commit standards
+lint-message				Validate a single commit message from a file, or stdin, eg. from a commit-msg hook
+install-hooks				Install a commit-msg hook running lint-message into the git repository
+lint-changelog				Validate that the changelog file follows keep a changelog rules
+fmt					Format the changelog file into its canonical layout
+yank					Mark a released version in the changelog file as yanked
+export					Export the changelog file as structured data, in json or yaml
 version					Print the tool version
 
 Global Options:
 
 LogLevel		-l, --log-level		Logging level verbosity, set at increasing level by calling the flag multiple times, eg. -lll will run at Info level. By default, runs at Fatal. The levels supported, in ascending verbosity are Fatal, Error, Warn, Info, and Debug.
 ChangelogFile		-f, --changelog-file 	Location of the changelog file at a path. Defaults to ./CHANGELOG.md
+Sections		--section		A section supported in the changelog, in the form <type>[=<heading>]. Set in order by calling the flag multiple times, eg. --section Added --section Fixed="Bug Fixes". Defaults to the keep a changelog sections
+CommitTypes		--commit-type		A custom conventional commit type, in the form <type>[=<increment>], eg. --commit-type security=PATCH. The increment is one of MAJOR, MINOR, or PATCH, and defaults to PATCH. Set the flag multiple times for multiple types
+TypeSections		--type-section		Record a conventional commit type under a section, in the form <type>=<section>, eg. --type-section perf=Changed. Set the flag multiple times for multiple types
+ExcludeTypes		--exclude-type		A conventional commit type to leave out of the changelog, eg. --exclude-type chore. Set the flag multiple times for multiple types
 Help			-h, --help		Print the help options for the selected operation`
 
 func main() {
@@ -47,6 +59,21 @@ func main() {
 		sLogger.Fatal(err.Error())
 	}
 
+	if err := configureChangeSections(options.Sections); err != nil {
+		sLogger.Error("failed to configure the changelog sections")
+		sLogger.Fatal(err.Error())
+	}
+
+	if err := configureCustomCommitTypes(options.CommitTypes); err != nil {
+		sLogger.Error("failed to configure the custom conventional commit types")
+		sLogger.Fatal(err.Error())
+	}
+
+	if err := configureCommitTypes(options.TypeSections, options.ExcludeTypes); err != nil {
+		sLogger.Error("failed to configure the conventional commit types")
+		sLogger.Fatal(err.Error())
+	}
+
 	operation := args[1]
 
 	switch operation {
@@ -55,12 +82,26 @@ func main() {
 		enforceUnreleased(options.ChangelogFile)
 	case "enforce-conventional-commits":
 		enforceConventionalCommits()
+	case "lint-message":
+		lintMessage()
+	case "install-hooks":
+		installHooks()
+	case "lint-changelog":
+		lintChangelog(options.ChangelogFile)
+	case "fmt":
+		formatChangelogFile()
+	case "yank":
+		yank()
+	case "export":
+		export()
+	case "init":
+		initChangelog()
 	case "new-version":
 		newVersion()
 	case "print-current-version":
 		printCurrentVersion(options.ChangelogFile)
 	case "print-unreleased-version":
-		printUnreleasedVersion(options.ChangelogFile)
+		printUnreleasedVersion()
 	case "print-current-changes":
 		printCurrentChanges(options.ChangelogFile)
 	case "print-unreleased-changes":
@@ -73,7 +114,10 @@ func main() {
 		update(true)
 		fallthrough
 	case "release":
-		release()
+		release(false)
+	case "promote":
+		promote()
+		release(true)
 	case "version":
 		fmt.Println(version)
 	default:
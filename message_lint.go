@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/leodido/go-conventionalcommits"
+)
+
+const (
+	commitMsgHook       = "commit-msg"
+	commitMsgHookMarker = "# changehelper commit-msg hook"
+	scissorsLine        = "# ------------------------ >8 ------------------------"
+)
+
+// autosquashPrefixes are the subjects git writes for commits that are squashed into another before
+// they land, so they aren't linted
+var autosquashPrefixes = []string{"fixup! ", "squash! ", "amend! "}
+
+// generatedPrefixes are the subjects git writes for merge and revert commits, which aren't linted as
+// the user doesn't write them
+var generatedPrefixes = []string{"Merge ", "Revert \""}
+
+// unsupportedCommitTypeError is returned for a conventional commit using a type that isn't supported
+type unsupportedCommitTypeError struct {
+	Type string
+}
+
+func (e unsupportedCommitTypeError) Error() string {
+	return fmt.Sprintf("has the unsupported conventional commit type %s", e.Type)
+}
+
+// validateCommitMessage checks that the message parses as a conventional commit, with a supported type
+func validateCommitMessage(machine conventionalcommits.Machine, message string) (*conventionalcommits.ConventionalCommit, error) {
+	ccMessage, err := machine.Parse([]byte(message))
+	if err != nil {
+		return nil, fmt.Errorf("was not a conventional commit, %w", err)
+	}
+	if ccMessage == nil || !ccMessage.Ok() {
+		return nil, errors.New("was not a conventional commit")
+	}
+
+	ccCommit, ok := ccMessage.(*conventionalcommits.ConventionalCommit)
+	if !ok {
+		return nil, errors.New("was not a conventional commit")
+	}
+	if !isSupportedCommitType(conventionalCommitType(strings.ToLower(ccCommit.Type))) {
+		return ccCommit, unsupportedCommitTypeError{Type: ccCommit.Type}
+	}
+
+	return ccCommit, nil
+}
+
+// cleanCommitMessage strips the comments git adds to the message file, and anything below the scissors
+// line added by commit --verbose, as git does when it records the commit
+func cleanCommitMessage(message string) string {
+	if scissors := strings.Index(message, scissorsLine); scissors >= 0 {
+		message = message[:scissors]
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n") {
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		lines = append(lines, strings.TrimRight(line, " \t"))
+	}
+
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+func lintMessage() {
+	var options LintMessageOptions
+	args := parseOptions(&options)
+
+	var data []byte
+	var err error
+	if len(args) > 2 {
+		sLogger.Debugf("reading commit message from file %s", args[2])
+		data, err = os.ReadFile(args[2])
+	} else {
+		sLogger.Debug("reading commit message from stdin")
+		data, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		sLogger.Error("failed to read the commit message")
+		sLogger.Fatal(err.Error())
+	}
+
+	message := cleanCommitMessage(string(data))
+	if message == "" {
+		sLogger.Fatal("the commit message is empty")
+	}
+
+	for _, prefix := range autosquashPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			sLogger.Infof("skipping commit message starting with %s, as it will be squashed", strings.TrimSpace(prefix))
+			return
+		}
+	}
+	for _, prefix := range generatedPrefixes {
+		if strings.HasPrefix(message, prefix) {
+			sLogger.Infof("skipping commit message starting with %s, as it is generated by git", strings.TrimSpace(prefix))
+			return
+		}
+	}
+
+	result := lintCommit(newConventionalCommitMachine(), mustParseCommitRules(options.CommitRuleOptions), "", message, ruleSeverityError)
+	if result.hasSeverity(ruleSeverityError) {
+		sLogger.Fatal(commitLintReport([]commitLintResult{result}))
+	} else if len(result.Violations) > 0 {
+		sLogger.Warn(commitLintReport([]commitLintResult{result}))
+	}
+}
+
+// shellQuote quotes the argument to be passed as is in a posix shell script
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+func installHooks() {
+	var options InstallHooksOptions
+	parseOptions(&options)
+
+	git := gitCli{
+		WorkingDirectory: options.GitWorkingDirectory,
+	}
+
+	hooksPath, err := git.getHooksPath()
+	if err != nil {
+		sLogger.Error("failed to find the git hooks directory")
+		sLogger.Fatal(err.Error())
+	}
+
+	hookFile := filepath.Join(*hooksPath, commitMsgHook)
+	if existing, err := os.ReadFile(hookFile); err == nil {
+		if !strings.Contains(string(existing), commitMsgHookMarker) && !options.Force {
+			sLogger.Fatalf("a %s hook already exists at %s, use --force to replace it", commitMsgHook, hookFile)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		sLogger.Errorf("failed to read the existing hook %s", hookFile)
+		sLogger.Fatal(err.Error())
+	}
+
+	command := []string{shellQuote(options.Command)}
+	for _, commitType := range options.CommitTypes {
+		command = append(command, "--commit-type", shellQuote(commitType))
+	}
+	command = append(command, "lint-message")
+	for _, rule := range mustParseCommitRules(options.CommitRuleOptions) {
+		ruleArg := rule.Name + ":" + rule.Severity
+		if rule.Value != "" {
+			ruleArg += "=" + rule.Value
+		}
+		command = append(command, "--rule", shellQuote(ruleArg))
+	}
+	command = append(command, `"$1"`)
+
+	hook := fmt.Sprintf("#!/bin/sh\n%s, installed by changehelper install-hooks\nexec %s\n", commitMsgHookMarker, strings.Join(command, " "))
+
+	if err := os.MkdirAll(*hooksPath, 0755); err != nil {
+		sLogger.Errorf("failed to create the git hooks directory %s", *hooksPath)
+		sLogger.Fatal(err.Error())
+	}
+	if err := os.WriteFile(hookFile, []byte(hook), 0755); err != nil {
+		sLogger.Errorf("failed to write the hook %s", hookFile)
+		sLogger.Fatal(err.Error())
+	}
+	// WriteFile keeps the mode of an existing file, so the hook is made executable explicitly
+	if err := os.Chmod(hookFile, 0755); err != nil {
+		sLogger.Errorf("failed to make the hook %s executable", hookFile)
+		sLogger.Fatal(err.Error())
+	}
+
+	fmt.Printf("installed %s hook at %s\n", commitMsgHook, hookFile)
+}
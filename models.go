@@ -5,7 +5,6 @@ import (
 	"strings"
 
 	"github.com/blang/semver"
-	"github.com/yuin/goldmark/ast"
 )
 
 const (
@@ -19,12 +18,18 @@ type change struct {
 	Version     *semver.Version
 	VersionText *string
 	Text        *string
-	Added       []string
-	Changed     []string
-	Deprecated  []string
-	Removed     []string
-	Fixed       []string
-	Security    []string
+	Span        *sourceSpan
+	Date        string
+	Yanked      bool
+	Description []string
+	Entries     map[changeType]*[]string
+}
+
+// sourceSpan is the byte range a change occupies in the changelog file,
+// from the start of its heading line to the end of its last line
+type sourceSpan struct {
+	Start int
+	End   int
 }
 
 const (
@@ -38,6 +43,125 @@ const (
 
 type changeType string
 
+// changeSection is a section of changes recorded under a release, identified by its type, and
+// rendered with its heading
+type changeSection struct {
+	Type    changeType
+	Heading string
+	Prompt  string
+}
+
+var defaultChangeSections = []changeSection{
+	{Type: changeAdded, Heading: string(changeAdded), Prompt: "added"},
+	{Type: changeChanged, Heading: string(changeChanged), Prompt: "changed"},
+	{Type: changeDeprecated, Heading: string(changeDeprecated), Prompt: "deprecated"},
+	{Type: changeRemoved, Heading: string(changeRemoved), Prompt: "removed"},
+	{Type: changeFixed, Heading: string(changeFixed), Prompt: "fixed"},
+	{Type: changeSecurity, Heading: string(changeSecurity), Prompt: "security related"},
+}
+
+// changeSections are the sections supported in the changelog, in the order they are rendered
+var changeSections = defaultChangeSections
+
+// configureChangeSections loads the sections from their cli form, <type>[=<heading>], eg. Fixed=Bug Fixes
+func configureChangeSections(sections []string) error {
+	if len(sections) == 0 {
+		return nil
+	}
+
+	configured := []changeSection{}
+	for _, section := range sections {
+		sectionSplit := strings.SplitN(section, "=", 2)
+		sectionType := changeType(strings.TrimSpace(sectionSplit[0]))
+		if sectionType == "" {
+			return fmt.Errorf("section '%s' has no type set", section)
+		}
+
+		changeSection := changeSection{
+			Type:    sectionType,
+			Heading: string(sectionType),
+			Prompt:  "related to " + strings.ToLower(string(sectionType)),
+		}
+		for _, defaultSection := range defaultChangeSections {
+			if strings.EqualFold(string(defaultSection.Type), string(sectionType)) {
+				changeSection.Type = defaultSection.Type
+				changeSection.Heading = defaultSection.Heading
+				changeSection.Prompt = defaultSection.Prompt
+			}
+		}
+
+		if len(sectionSplit) > 1 && strings.TrimSpace(sectionSplit[1]) != "" {
+			changeSection.Heading = strings.TrimSpace(sectionSplit[1])
+		}
+
+		for _, existing := range configured {
+			if existing.Type == changeSection.Type {
+				return fmt.Errorf("section '%s' is configured more than once", changeSection.Type)
+			}
+		}
+
+		configured = append(configured, changeSection)
+	}
+
+	changeSections = configured
+	sLogger.Debugf("configured changelog sections as %v", changeSections)
+
+	return nil
+}
+
+// findChangeSection looks up a configured section matching the type, or the heading of the section
+func findChangeSection(text string) *changeSection {
+	deformattedText := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(text)), " ", "")
+	for idx, section := range changeSections {
+		if deformattedText == strings.ReplaceAll(strings.ToLower(section.Heading), " ", "") ||
+			deformattedText == strings.ReplaceAll(strings.ToLower(string(section.Type)), " ", "") {
+			return &changeSections[idx]
+		}
+	}
+
+	return nil
+}
+
+func (c *change) sectionEntries(sectionType changeType) *[]string {
+	if c.Entries == nil {
+		c.Entries = map[changeType]*[]string{}
+	}
+
+	entries, ok := c.Entries[sectionType]
+	if !ok {
+		entries = &[]string{}
+		c.Entries[sectionType] = entries
+	}
+
+	return entries
+}
+
+// addEntries records entries under a section, as long as the section is configured for the changelog
+func (c *change) addEntries(sectionType changeType, entries ...string) {
+	if len(entries) == 0 {
+		return
+	}
+
+	for _, section := range changeSections {
+		if section.Type == sectionType {
+			*c.sectionEntries(sectionType) = append(*c.sectionEntries(sectionType), entries...)
+			return
+		}
+	}
+
+	sLogger.Warnf("section %s is not configured for the changelog, skipping %d entries", sectionType, len(entries))
+}
+
+func (c *change) hasEntries() bool {
+	for _, section := range changeSections {
+		if len(*c.sectionEntries(section.Type)) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *change) renderChangeText(increment ...string) {
 	sb := strings.Builder{}
 	versionText := releasePrefix + "[Unreleased]"
@@ -46,45 +170,52 @@ func (c *change) renderChangeText(increment ...string) {
 	}
 	versionText = versionText + "\n"
 	c.VersionText = &versionText
-	appendSection := func(text changeType, section []string) {
-		if len(section) > 0 {
-			sb.WriteString(fmt.Sprintf("%s%s\n", changePrefix, text))
+	if len(c.Description) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", strings.Join(c.Description, "\n")))
+	}
+
+	for _, changeSection := range changeSections {
+		if section := *c.sectionEntries(changeSection.Type); len(section) > 0 {
+			sb.WriteString(fmt.Sprintf("%s%s\n", changePrefix, changeSection.Heading))
 			sb.WriteString(fmt.Sprintf("%s\n", strings.Join(section, "\n")))
 		}
 	}
 
-	appendSection(changeAdded, c.Added)
-	appendSection(changeChanged, c.Changed)
-	appendSection(changeDeprecated, c.Deprecated)
-	appendSection(changeRemoved, c.Removed)
-	appendSection(changeFixed, c.Fixed)
-	appendSection(changeSecurity, c.Security)
-
-	text := sb.String()
-	text = text[:len(text)-1]
+	text := strings.TrimSuffix(sb.String(), "\n")
 	c.Text = &text
 
 	sLogger.Debug("rendered new release text as:")
 	sLogger.Debug(text)
 }
 
-func loopChildren(node ast.Node, source []byte) []string {
-	text := []string{}
-
-	if !node.HasChildren() {
-		return text
+func (c *change) mergeEntries(source *change) {
+	if names := source.contributorNames(); len(names) > 0 {
+		c.addContributors(names...)
 	}
 
-	current := node.FirstChild()
-	for {
-		if current == nil {
-			break
+	for _, section := range changeSections {
+		target := c.sectionEntries(section.Type)
+		for _, entry := range *source.sectionEntries(section.Type) {
+			entry = strings.TrimRight(entry, "\n")
+
+			isDuplicate := false
+			for _, existing := range *target {
+				if strings.TrimRight(existing, "\n") == entry {
+					isDuplicate = true
+					break
+				}
+			}
+
+			if !isDuplicate {
+				// Any blank lines after the last entry separate the section from what follows, so
+				// they move to the end of the appended entry
+				if last := len(*target) - 1; last >= 0 {
+					trimmed := strings.TrimRight((*target)[last], "\n")
+					entry = entry + (*target)[last][len(trimmed):]
+					(*target)[last] = trimmed
+				}
+				*target = append(*target, entry)
+			}
 		}
-
-		text = append(text, string(current.Text(source)))
-
-		current = current.NextSibling()
 	}
-
-	return text
 }
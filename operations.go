@@ -4,11 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/blang/semver"
-	"github.com/leodido/go-conventionalcommits"
-	"github.com/leodido/go-conventionalcommits/parser"
 	"github.com/manifoldco/promptui"
 )
 
@@ -34,17 +34,19 @@ func newVersion() {
 		sLogger.Fatal(err.Error())
 	}
 
-	_, unreleased, _, released, err := parseChangelog(options.ChangelogFile)
+	changelog, unreleased, unreleasedIncrement, released, err := parseChangelog(options.ChangelogFile)
 	if err != nil {
 		sLogger.Errorf("failed to read the changelog file %s", options.ChangelogFile)
 		sLogger.Fatal(err.Error())
 	}
 
 	if unreleased != nil {
-		if options.Force {
+		if options.Merge {
+			sLogger.Info("there is a pending release, going to merge with incoming")
+		} else if options.Force {
 			sLogger.Warn("there is a pending release, going to replace with incoming")
 		} else {
-			sLogger.Fatal("a pending version already exists in the changelog")
+			sLogger.Fatal("a pending version already exists in the changelog, use --merge to add to it, or --force to replace it")
 		}
 	}
 
@@ -52,17 +54,33 @@ func newVersion() {
 	newChange := change{
 		VersionText: &unreleasedVersionText,
 	}
+	if unreleased != nil {
+		newChange.Span = unreleased.Span
+	}
 	increment := ""
 
 	if mustGitResolveQuery(options.NonInteractive, options.Manual) {
 		resolveVersionFromGit(options, git, &newChange, &increment)
 	} else if !options.NonInteractive {
-		mustCaptureMultiLineInput("Was anything added this release?", "Was anything more added this release?", "Describe what was added in this release", &newChange.Added)
-		mustCaptureMultiLineInput("Was anything changed this release?", "Was anything more changed this release?", "Describe what was changed in this release", &newChange.Changed)
-		mustCaptureMultiLineInput("Was anything deprecated this release?", "Was anything more deprecated this release?", "Describe what was deprecated in this release", &newChange.Deprecated)
-		mustCaptureMultiLineInput("Was anything removed this release?", "Was anything more removed this release?", "Describe what was removed in this release", &newChange.Removed)
-		mustCaptureMultiLineInput("Was anything fixed this release?", "Was anything more fixed this release?", "Describe what was fixed in this release", &newChange.Fixed)
-		mustCaptureMultiLineInput("Was anything security related this release?", "Was anything more security related this release?", "Describe what was security related in this release", &newChange.Security)
+		for _, section := range changeSections {
+			mustCaptureMultiLineInput(
+				fmt.Sprintf("Was anything %s this release?", section.Prompt),
+				fmt.Sprintf("Was anything more %s this release?", section.Prompt),
+				fmt.Sprintf("Describe what was %s in this release", section.Prompt),
+				newChange.sectionEntries(section.Type),
+			)
+		}
+	}
+
+	if err := loadManualEntriesToChange(options, &newChange); err != nil {
+		sLogger.Error("failed to load the entries set for the new version")
+		sLogger.Fatal(err.Error())
+	}
+
+	mustLinkReferences(options.ReferenceLinkOptions, &newChange, git)
+
+	if options.Merge && unreleased != nil && unreleasedIncrement != nil {
+		increment = higherIncrement(*unreleasedIncrement, increment, options.Increment)
 	}
 
 	if options.Increment == "" && increment == "" {
@@ -82,15 +100,59 @@ func newVersion() {
 		}
 
 		increment = pIncrement
+	} else if increment == "" {
+		increment = options.Increment
+	}
+
+	if options.Merge && unreleased != nil {
+		sLogger.Debug("merging incoming changes into the pending release")
+		// The pending entries are linked as the incoming ones are, so the same entry is only recorded once
+		mustLinkReferences(options.ReferenceLinkOptions, unreleased, git)
+		unreleased.mergeEntries(&newChange)
+		newChange = *unreleased
 	}
 
 	newChange.renderChangeText(increment)
 
-	if err := writeToChangelogFile(options.ChangelogFile, &newChange, released, false); err != nil {
+	if err := writeToChangelogFile(options.ChangelogFile, changelog, &newChange, released, false); err != nil {
 		sLogger.Fatal(err.Error())
 	}
 }
 
+// loadManualEntriesToChange adds the entries set by cli flags to the new version
+func loadManualEntriesToChange(options NewVersionOptions, newChange *change) error {
+	toEntries := func(messages []string) []string {
+		entries := []string{}
+		for _, message := range messages {
+			entries = append(entries, linePrefix+message)
+		}
+		return entries
+	}
+
+	newChange.addEntries(changeAdded, toEntries(options.Added)...)
+	newChange.addEntries(changeChanged, toEntries(options.Changed)...)
+	newChange.addEntries(changeDeprecated, toEntries(options.Deprecated)...)
+	newChange.addEntries(changeRemoved, toEntries(options.Removed)...)
+	newChange.addEntries(changeFixed, toEntries(options.Fixed)...)
+	newChange.addEntries(changeSecurity, toEntries(options.Security)...)
+
+	for _, entry := range options.Entries {
+		entrySplit := strings.SplitN(entry, "=", 2)
+		if len(entrySplit) != 2 {
+			return fmt.Errorf("entry '%s' is not in the form <type>=<entry>", entry)
+		}
+
+		section := findChangeSection(entrySplit[0])
+		if section == nil {
+			return fmt.Errorf("entry '%s' is for a section that is not configured", entry)
+		}
+
+		newChange.addEntries(section.Type, toEntries([]string{entrySplit[1]})...)
+	}
+
+	return nil
+}
+
 func mustGitResolveQuery(nonInteractive, manual bool) bool {
 	if !nonInteractive {
 		if manual {
@@ -131,6 +193,8 @@ func resolveVersionFromGit(options NewVersionOptions, git gitCli, newChange *cha
 			options.ChangelogFile,
 			options.Depth,
 			options.AuditClogFile,
+			options.EntryStyleOptions,
+			newCommitFilter(options.CommitFilterOptions, git),
 			newChange,
 			git,
 		)
@@ -140,7 +204,9 @@ func resolveVersionFromGit(options NewVersionOptions, git gitCli, newChange *cha
 			return false
 		}
 
-		increment = ccIncrement
+		if ccIncrement != nil {
+			*increment = *ccIncrement
+		}
 
 		return true
 	}()
@@ -153,13 +219,13 @@ func resolveVersionFromGit(options NewVersionOptions, git gitCli, newChange *cha
 		}
 
 		for _, added := range diff.Added {
-			newChange.Added = append(newChange.Added, "- "+added)
+			newChange.addEntries(changeAdded, "- "+added)
 		}
 		for _, changed := range diff.Changed {
-			newChange.Changed = append(newChange.Changed, "- "+changed)
+			newChange.addEntries(changeChanged, "- "+changed)
 		}
 		for _, removed := range diff.Removed {
-			newChange.Removed = append(newChange.Removed, "- "+removed)
+			newChange.addEntries(changeRemoved, "- "+removed)
 		}
 	}
 }
@@ -178,12 +244,13 @@ func update(ignoreUnknown bool) {
 		git.checkoutAndPull(options.GitBranch)
 	}
 
-	_, unreleased, increment, released, err := parseChangelog(options.ChangelogFile)
+	changelog, unreleased, increment, released, err := parseChangelog(options.ChangelogFile)
 	if err != nil {
 		sLogger.Error("failed to parse the changelog file")
 		sLogger.Fatal(err.Error())
 	}
 
+	knownReleases := released
 	if options.GitEvaluate {
 		gitVersions, err := listReleasedVersionFromGit(options.UseTags, git, options.GitPrefix)
 		if err != nil {
@@ -191,17 +258,21 @@ func update(ignoreUnknown bool) {
 			sLogger.Fatal(err.Error())
 		}
 
-		for _, gitVersion := range gitVersions {
-			released = append(released, &change{
-				Version: &gitVersion,
+		for idx := range gitVersions {
+			if isYanked(gitVersions[idx], released) {
+				sLogger.Debugf("skipping git release %s as it is yanked in the changelog", gitVersions[idx].String())
+				continue
+			}
+
+			knownReleases = append(knownReleases, &change{
+				Version: &gitVersions[idx],
 				Text:    nil,
 			})
 		}
 	}
 
 	var latestRelease change
-	if len(released) > 0 {
-		foundLatestRelease := getLatestRelease(released)
+	if foundLatestRelease := getLatestRelease(knownReleases); foundLatestRelease != nil {
 		latestRelease = *foundLatestRelease
 	} else {
 		latestRelease = change{
@@ -210,9 +281,11 @@ func update(ignoreUnknown bool) {
 		}
 	}
 
+	loadedCommits := unreleased == nil
 	if unreleased == nil {
+		latestVersion := *latestRelease.Version
 		unreleased = &change{
-			Version: latestRelease.Version,
+			Version: &latestVersion,
 		}
 
 		increment, err = loadConventionalCommitsToChange(
@@ -220,6 +293,8 @@ func update(ignoreUnknown bool) {
 			options.ChangelogFile,
 			options.Depth,
 			options.AuditClogFile,
+			options.EntryStyleOptions,
+			newCommitFilter(options.CommitFilterOptions, git),
 			unreleased,
 			git,
 		)
@@ -227,27 +302,22 @@ func update(ignoreUnknown bool) {
 			sLogger.Fatal(err.Error())
 		}
 
-		if len(unreleased.Added) == 0 && len(unreleased.Changed) == 0 && len(unreleased.Deprecated) == 0 && len(unreleased.Removed) == 0 && len(unreleased.Fixed) == 0 && len(unreleased.Security) == 0 {
+		if !unreleased.hasEntries() {
 			sLogger.Info("No trackable changes to be added to the changelog file. Exiting without changes.")
 			os.Exit(0)
 		}
 
+		mustLinkReferences(options.ReferenceLinkOptions, unreleased, git)
 		unreleased.renderChangeText(*increment)
 	}
 
 	if increment == nil {
 		combinedMessages := []string{}
-		combineCommits := func(messages []string) {
-			for _, message := range messages {
-				combinedMessages = append(combinedMessages, strings.TrimPrefix(message, "- "))
+		for _, section := range changeSections {
+			for _, entry := range *unreleased.sectionEntries(section.Type) {
+				combinedMessages = append(combinedMessages, entryCommitMessage(section.Type, entry))
 			}
 		}
-		combineCommits(unreleased.Added)
-		combineCommits(unreleased.Changed)
-		combineCommits(unreleased.Deprecated)
-		combineCommits(unreleased.Fixed)
-		combineCommits(unreleased.Removed)
-		combineCommits(unreleased.Security)
 
 		increment, _ = parseConventionalCommitMessages(combinedMessages...)
 
@@ -258,18 +328,79 @@ func update(ignoreUnknown bool) {
 		sLogger.Debug(*unreleased.Text)
 	}
 
+	// An existing unreleased change is written as it is, unless its references are to be linked
+	if options.LinkReferences && !loadedCommits {
+		mustLinkReferences(options.ReferenceLinkOptions, unreleased, git)
+		unreleased.renderChangeText(*increment)
+	}
+
 	if unreleased.Version == nil {
-		unreleased.Version = latestRelease.Version
+		latestVersion := *latestRelease.Version
+		unreleased.Version = &latestVersion
 	}
 
 	updateUnreleasedVersion(unreleased, increment)
+	skipYankedVersions(unreleased, increment, knownReleases)
+
+	if options.Prerelease != "" {
+		if err := setPrereleaseVersion(unreleased.Version, options.Prerelease, knownReleases); err != nil {
+			sLogger.Errorf("failed to set the pre-release identifier %s", options.Prerelease)
+			sLogger.Fatal(err.Error())
+		}
+	}
 
 	sLogger.Debug("Updating unrleased to:")
 	sLogger.Debug(*unreleased.Text)
 
-	if err := writeToChangelogFile(options.ChangelogFile, unreleased, released, true); err != nil {
+	compareLinks := options.CompareLinks || (!options.NoCompareLinks && hasCompareLinks(changelog))
+
+	if err := writeToChangelogFile(options.ChangelogFile, changelog, unreleased, released, true); err != nil {
 		sLogger.Fatal(err.Error())
 	}
+
+	if compareLinks {
+		compareURL, err := resolveCompareURL(options.CompareURL, git)
+		if err != nil {
+			sLogger.Error("failed to resolve the base url for compare links")
+			sLogger.Fatal(err.Error())
+		}
+
+		if err := updateCompareLinks(options.ChangelogFile, compareURL, options.GitPrefix, options.VersionPrefix, *unreleased.Version, knownReleases); err != nil {
+			sLogger.Fatal(err.Error())
+		}
+	}
+}
+
+// commitEntryRegex matches the entries written in the commits style, by the short hash of their commit,
+// eg. '**parser:** handle empty input (1a2b3c4) by Jane Doe'
+var commitEntryRegex = regexp.MustCompile(`^.+ \([0-9a-f]{7}\)(?: by .+)?$`)
+
+// entryCommitMessage recovers the conventional commit an entry was recorded from, to resolve the
+// increment of an unreleased change without one. Files style entries hold the commit message after
+// the file, eg. '- operations.go; fix: handle empty input', while commits style entries don't hold
+// the type, so an added entry is taken as a feat, and any other entry as a fix. Any other entry is
+// left as it is, so that it can't resolve an increment
+func entryCommitMessage(section changeType, entry string) string {
+	message := strings.TrimPrefix(strings.SplitN(entry, "\n", 2)[0], "- ")
+	if fileSplit := strings.SplitN(message, "; ", 2); len(fileSplit) > 1 && isConventionalCommit(fileSplit[1]) {
+		return fileSplit[1]
+	}
+	if isConventionalCommit(message) {
+		return message
+	}
+
+	if breakingChange := strings.TrimPrefix(message, "BREAKING CHANGE: "); breakingChange != message {
+		return fmt.Sprintf("%s!: %s", conventionalCommitFeat, breakingChange)
+	}
+
+	if !commitEntryRegex.MatchString(message) {
+		return message
+	}
+	if section == changeAdded {
+		return fmt.Sprintf("%s: %s", conventionalCommitFeat, message)
+	}
+
+	return fmt.Sprintf("%s: %s", conventionalCommitFix, message)
 }
 
 func loadConventionalCommitsToChange(
@@ -277,40 +408,122 @@ func loadConventionalCommitsToChange(
 	changelogFile string,
 	depth int,
 	auditClogFile bool,
+	entryStyle EntryStyleOptions,
+	filter commitFilter,
 	change *change,
 	git gitCli,
 ) (*string, error) {
-	increment, fixedUnique, addedUnique, changedUnique, removedUnique, err := resolveConventionalCommits(git, changelogFile, depth)
+	changes, err := resolveConventionalCommits(git, changelogFile, depth, filter, entryStyle.CommitAuthors || entryStyle.Contributors)
 	if err != nil {
 		sLogger.Error("failed to lookup conventional commits when running update")
 		return nil, err
 	}
 
-	for fixed, message := range fixedUnique {
-		if dir+fixed != changelogFile || auditClogFile {
-			change.Fixed = append(change.Fixed, fmt.Sprintf("- %s; %s", fixed, message))
-		}
+	if err := addConventionalCommitEntries(dir, changelogFile, auditClogFile, entryStyle, change, *changes); err != nil {
+		return nil, err
+	}
+
+	return changes.Increment, nil
+}
+
+const (
+	entryStyleFiles   = "files"
+	entryStyleCommits = "commits"
+)
+
+// addConventionalCommitEntries records the changes from conventional commits under their sections,
+// along with any breaking changes. By default, there is an entry per changed file, sorted by file so
+// the entries are stable between runs, otherwise there is an entry per commit
+func addConventionalCommitEntries(dir, changelogFile string, auditClogFile bool, entryStyle EntryStyleOptions, change *change, changes conventionalCommitChanges) error {
+	isChangelogFile := func(file string) bool {
+		return dir+file == changelogFile && !auditClogFile
 	}
 
-	for added, message := range addedUnique {
-		if dir+added != changelogFile || auditClogFile {
-			change.Added = append(change.Added, fmt.Sprintf("- %s; %s", added, message))
+	for _, breakingChange := range changes.Breaking {
+		change.addEntries(changeChanged, fmt.Sprintf("- BREAKING CHANGE: %s", breakingChange))
+	}
+
+	contributors := []gitContributor{}
+	switch entryStyle.EntryStyle {
+	case entryStyleFiles, "":
+		for _, section := range changeSections {
+			files := []string{}
+			for file := range changes.Files[section.Type] {
+				if !isChangelogFile(file) {
+					files = append(files, file)
+				}
+			}
+			sort.Strings(files)
+
+			for _, file := range files {
+				fileContributors := []gitContributor{}
+				for _, hash := range changes.FileCommits[section.Type][file] {
+					fileContributors = appendContributors(fileContributors, changes.Contributors[hash]...)
+				}
+				contributors = appendContributors(contributors, fileContributors...)
+
+				entry := fmt.Sprintf("- %s; %s", file, changes.Files[section.Type][file])
+				if entryStyle.CommitAuthors {
+					entry = creditEntry(entry, fileContributors)
+				}
+				change.addEntries(section.Type, entry)
+			}
+		}
+	case entryStyleCommits:
+		for _, commit := range changes.Commits {
+			files := []string{}
+			for _, file := range commit.Files {
+				if !isChangelogFile(file) {
+					files = append(files, file)
+				}
+			}
+			if len(commit.Files) > 0 && len(files) == 0 {
+				continue
+			}
+
+			contributors = appendContributors(contributors, commit.Contributors...)
+			change.addEntries(commit.Section, commitEntryText(commit, files, entryStyle))
 		}
+	default:
+		return fmt.Errorf("entry style %s is not supported, only %s and %s are supported", entryStyle.EntryStyle, entryStyleFiles, entryStyleCommits)
 	}
 
-	for changed, message := range changedUnique {
-		if dir+changed != changelogFile || auditClogFile {
-			change.Changed = append(change.Changed, fmt.Sprintf("- %s; %s", changed, message))
+	if entryStyle.Contributors && len(contributors) > 0 {
+		names := []string{}
+		for _, contributor := range contributors {
+			names = append(names, contributor.displayName())
 		}
+		change.addContributors(names...)
+	}
+
+	return nil
+}
+
+// commitEntryText renders a commit as an entry, eg. '- **api:** support pagination (1a2b3c4) by Jane Doe',
+// with the changed files as a nested list if they are included
+func commitEntryText(commit conventionalCommitEntry, files []string, entryStyle EntryStyleOptions) string {
+	sb := strings.Builder{}
+	sb.WriteString(linePrefix)
+	if commit.Scope != "" {
+		sb.WriteString(fmt.Sprintf("**%s:** ", commit.Scope))
+	}
+	sb.WriteString(commit.Description)
+
+	if !entryStyle.NoCommitHash && commit.Hash != "" {
+		sb.WriteString(fmt.Sprintf(" (%s)", shortHash(commit.Hash)))
 	}
 
-	for removed, message := range removedUnique {
-		if dir+removed != changelogFile || auditClogFile {
-			change.Removed = append(change.Removed, fmt.Sprintf("- %s; %s", removed, message))
+	if entryStyle.CommitAuthors && len(commit.Contributors) > 0 {
+		sb.WriteString(fmt.Sprintf(" by %s", contributorsText(commit.Contributors)))
+	}
+
+	if entryStyle.CommitFiles {
+		for _, file := range files {
+			sb.WriteString(fmt.Sprintf("\n  %s%s", linePrefix, file))
 		}
 	}
 
-	return increment, nil
+	return sb.String()
 }
 
 func printCurrentVersion(changelogFile string) {
@@ -323,16 +536,82 @@ func printCurrentVersion(changelogFile string) {
 	os.Exit(0)
 }
 
-func printUnreleasedVersion(changelogFile string) {
-	_, unreleasedVersion, err := getUnreleased(changelogFile)
+func printUnreleasedVersion() {
+	var options PrintUnreleasedVersionOptions
+	parseOptions(&options)
+
+	_, unreleasedVersion, err := getUnreleased(options.ChangelogFile)
 	if err != nil {
-		sLogger.Fatal(err.Error())
+		git := gitCli{
+			WorkingDirectory: options.GitWorkingDirectory,
+		}
+
+		// Only a component filtered by scope or path resolves its version from commits, otherwise there
+		// has to be an unreleased change in the changelog
+		filter := newCommitFilter(options.CommitFilterOptions, git)
+		if !filter.isSet() {
+			sLogger.Fatal(err.Error())
+		}
+
+		sLogger.Infof("failed to get the unreleased version from the changelog, trying conventional commits: %s", err.Error())
+
+		unreleasedVersion, err = resolveUnreleasedVersionFromCommits(options, filter, git)
+		if err != nil {
+			sLogger.Fatal(err.Error())
+		}
 	}
 
 	fmt.Print(unreleasedVersion.String())
 	os.Exit(0)
 }
 
+// resolveUnreleasedVersionFromCommits calculates the next version from the conventional commits since
+// the last change to the changelog, without updating the changelog, failing if the commits don't
+// resolve to an increment
+func resolveUnreleasedVersionFromCommits(options PrintUnreleasedVersionOptions, filter commitFilter, git gitCli) (*semver.Version, error) {
+	_, _, _, released, err := parseChangelog(options.ChangelogFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if options.GitEvaluate {
+		gitVersions, err := listReleasedVersionFromGit(options.UseTags, git, options.GitPrefix)
+		if err != nil {
+			return nil, err
+		}
+
+		for idx := range gitVersions {
+			if !isYanked(gitVersions[idx], released) {
+				released = append(released, &change{
+					Version: &gitVersions[idx],
+				})
+			}
+		}
+	}
+
+	changes, err := resolveConventionalCommits(git, options.ChangelogFile, options.Depth, filter, false)
+	if err != nil {
+		return nil, err
+	}
+	if changes.Increment == nil {
+		return nil, errors.New("no conventional commits matching the filters were found to resolve the unreleased version from")
+	}
+
+	unreleased := &change{}
+	if latest := getLatestRelease(released); latest != nil {
+		latestVersion := *latest.Version
+		unreleased.Version = &latestVersion
+	} else {
+		defaultVersion := semver.MustParse("0.0.0")
+		unreleased.Version = &defaultVersion
+	}
+
+	updateUnreleasedVersion(unreleased, changes.Increment)
+	skipYankedVersions(unreleased, changes.Increment, released)
+
+	return unreleased.Version, nil
+}
+
 func printCurrentChanges(changelogFile string) {
 	currentText, _, err := getCurrent(changelogFile)
 	if err != nil {
@@ -370,23 +649,31 @@ func printChanges() {
 
 	filteredReleases := []*change{}
 
-	switch strings.Count(options.Version, ".") {
-	case 0:
+	if len(ver.Pre) > 0 || len(ver.Build) > 0 {
 		for _, release := range released {
-			if release.Version.Major == ver.Major {
+			if release.Version.Equals(ver) && (len(ver.Build) == 0 || strings.Join(release.Version.Build, ".") == strings.Join(ver.Build, ".")) {
 				filteredReleases = append(filteredReleases, release)
 			}
 		}
-	case 1:
-		for _, release := range released {
-			if release.Version.Major == ver.Major && release.Version.Minor == ver.Minor {
-				filteredReleases = append(filteredReleases, release)
+	} else {
+		switch strings.Count(options.Version, ".") {
+		case 0:
+			for _, release := range released {
+				if release.Version.Major == ver.Major {
+					filteredReleases = append(filteredReleases, release)
+				}
 			}
-		}
-	default:
-		for _, release := range released {
-			if release.Version.Major == ver.Major && release.Version.Minor == ver.Minor && release.Version.Patch == ver.Patch {
-				filteredReleases = append(filteredReleases, release)
+		case 1:
+			for _, release := range released {
+				if release.Version.Major == ver.Major && release.Version.Minor == ver.Minor {
+					filteredReleases = append(filteredReleases, release)
+				}
+			}
+		default:
+			for _, release := range released {
+				if release.Version.Major == ver.Major && release.Version.Minor == ver.Minor && release.Version.Patch == ver.Patch {
+					filteredReleases = append(filteredReleases, release)
+				}
 			}
 		}
 	}
@@ -398,9 +685,9 @@ func printChanges() {
 	}
 }
 
-func release() {
+func release(ignoreUnknown bool) {
 	var options ReleaseOptions
-	parseOptions(&options)
+	parseOptions(&options, ignoreUnknown)
 
 	git := gitCli{
 		WorkingDirectory: options.GitWorkingDirectory,
@@ -450,6 +737,163 @@ func release() {
 	}
 }
 
+func promote() {
+	var options PromoteOptions
+	parseOptions(&options)
+
+	// The changelog is checked out and pulled before it is read, so the promotion is written on top of
+	// the latest changes, rather than being overwritten by them when released
+	if !options.SkipGitCheckout {
+		git := gitCli{
+			WorkingDirectory: options.GitWorkingDirectory,
+		}
+
+		branch := mustHaveBranch(options.GitBranch, "What git branch should be released from?", options.NonInteractive, git)
+		if err := git.checkoutAndPull(branch); err != nil {
+			sLogger.Fatal(err.Error())
+		}
+	}
+
+	changelog, unreleased, _, released, err := parseChangelog(options.ChangelogFile)
+	if err != nil {
+		sLogger.Fatal(err.Error())
+	}
+
+	if unreleased != nil {
+		sLogger.Fatal("there is a pending unreleased change in the changelog, it must be released before promoting")
+	}
+
+	var promoteVersion semver.Version
+	if options.PromoteVersion != "" {
+		promoteVersion, err = semver.ParseTolerant(options.PromoteVersion)
+		if err != nil {
+			sLogger.Errorf("could not parse the promote version %s", options.PromoteVersion)
+			sLogger.Fatal(err.Error())
+		}
+	} else {
+		latest := getLatestRelease(released)
+		if latest == nil || len(latest.Version.Pre) == 0 {
+			sLogger.Fatal("the latest release in the changelog is not a pre-release, so there is nothing to promote")
+		}
+		promoteVersion = *latest.Version
+	}
+	promoteVersion.Pre = nil
+	promoteVersion.Build = nil
+
+	prereleases := []*change{}
+	for _, release := range released {
+		if release.Version.Equals(promoteVersion) {
+			sLogger.Fatalf("version %s has already been released in the changelog", promoteVersion.String())
+		}
+
+		isPrerelease := len(release.Version.Pre) > 0 &&
+			release.Version.Major == promoteVersion.Major &&
+			release.Version.Minor == promoteVersion.Minor &&
+			release.Version.Patch == promoteVersion.Patch &&
+			(options.Prerelease == "" || strings.HasPrefix(prereleaseText(release.Version)+".", options.Prerelease+"."))
+
+		if isPrerelease {
+			prereleases = append(prereleases, release)
+		}
+	}
+
+	if len(prereleases) == 0 {
+		sLogger.Fatalf("no pre-releases of version %s were found in the changelog", promoteVersion.String())
+	}
+
+	sort.SliceStable(prereleases, func(i, j int) bool {
+		return prereleases[i].Version.LT(*prereleases[j].Version)
+	})
+
+	promoted := &change{
+		Version: &promoteVersion,
+	}
+	for _, prerelease := range prereleases {
+		sLogger.Infof("merging changes from %s into %s", prerelease.Version.String(), promoteVersion.String())
+		promoted.mergeEntries(prerelease)
+	}
+
+	promoted.renderChangeText()
+
+	removed := []*change{}
+	if options.Collapse {
+		removed = prereleases
+	}
+
+	if err := writeToChangelogFile(options.ChangelogFile, changelog, promoted, released, true, removed...); err != nil {
+		sLogger.Fatal(err.Error())
+	}
+}
+
+func yank() {
+	var options YankOptions
+	args := parseOptions(&options)
+
+	if len(args) < 3 {
+		sLogger.Fatal("a version to yank must be provided, eg. changehelper yank 1.2.3")
+	}
+
+	version, err := semver.ParseTolerant(args[2])
+	if err != nil {
+		sLogger.Errorf("could not parse the input version %s", args[2])
+		sLogger.Fatal(err.Error())
+	}
+
+	changelog, _, _, released, err := parseChangelog(options.ChangelogFile)
+	if err != nil {
+		sLogger.Fatal(err.Error())
+	}
+
+	var yanked *change
+	for _, release := range released {
+		if release.Version.String() == version.String() {
+			yanked = release
+			break
+		}
+	}
+
+	if yanked == nil {
+		sLogger.Fatalf("version %s could not be found in the changelog", version.String())
+	}
+
+	if yanked.Yanked {
+		sLogger.Infof("version %s is already yanked in the changelog", version.String())
+	} else {
+		headingEnd := lineEnd(changelog, yanked.Span.Start+1)
+		heading := strings.TrimRight(string(changelog[yanked.Span.Start:headingEnd]), "\r\n")
+
+		edit := changelogEdit{
+			Start: yanked.Span.Start,
+			End:   headingEnd,
+			Text:  heading + " [YANKED]\n",
+		}
+
+		if err := os.WriteFile(options.ChangelogFile, applyChangelogEdits(changelog, []changelogEdit{edit}), 0644); err != nil {
+			sLogger.Errorf("failed to write to changelog file %s", options.ChangelogFile)
+			sLogger.Fatal(err.Error())
+		}
+	}
+
+	if options.DeleteRef {
+		git := gitCli{
+			WorkingDirectory: options.GitWorkingDirectory,
+		}
+
+		remote := getRemote(git)
+		patchRef := releaseRefName(options.GitPrefix, options.VersionPrefix, version)
+
+		if options.UseTags {
+			err = git.deleteTag(remote, patchRef)
+		} else {
+			err = git.deleteBranch(remote, patchRef)
+		}
+		if err != nil {
+			sLogger.Errorf("failed to delete the release ref %s", patchRef)
+			sLogger.Fatal(err.Error())
+		}
+	}
+}
+
 func enforceUnreleased(changelogFile string) {
 	_, unreleased, _, _, err := parseChangelog(changelogFile)
 	if err != nil {
@@ -469,6 +913,23 @@ func createOrUpdateReleaseRefs(useTags bool, branch, gitPrefix, versionPrefix st
 	patchRef := fmt.Sprintf("%s.%d", minorRef, version.Patch)
 
 	errs := []error{}
+	if len(version.Pre) > 0 {
+		sLogger.Infof("version %s is a pre-release, only the patch ref will be updated/created", version.String())
+		patchRef = fmt.Sprintf("%s/%s%s", gitPrefix, versionPrefix, version.String())
+
+		if useTags {
+			if err := git.resetTag(remote, patchRef); err != nil {
+				errs = append(errs, errors.New("failed to update/create the pre-release tag"))
+			}
+		} else {
+			if err := git.resetBranch(remote, branch, patchRef); err != nil {
+				errs = append(errs, errors.New("failed to update/create the pre-release branch"))
+			}
+		}
+
+		return errs
+	}
+
 	if useTags {
 		if err := git.resetTag(remote, majorRef); err != nil {
 			errs = append(errs, errors.New("failed to update/create the major tag"))
@@ -498,6 +959,13 @@ func enforceConventionalCommits() {
 	var options EnforceConventionalCommitsOptions
 	parseOptions(&options)
 
+	if options.ReportFormat != "" || options.ReportFile != "" {
+		if err := validateReportFormat(options.ReportFormat); err != nil {
+			sLogger.Error("failed to setup the commit report")
+			sLogger.Fatal(err.Error())
+		}
+	}
+
 	git := gitCli{
 		WorkingDirectory: options.GitWorkingDirectory,
 	}
@@ -536,38 +1004,54 @@ func enforceConventionalCommits() {
 		}
 	}
 
-	machineOptions := []conventionalcommits.MachineOption{
-		conventionalcommits.WithTypes(conventionalcommits.TypesConventional),
-		conventionalcommits.WithBestEffort(),
+	rules := mustParseCommitRules(options.CommitRuleOptions)
+	machine := newConventionalCommitMachine()
+
+	// Non conventional commits are only reported as warnings when they are allowed
+	parseSeverity := ruleSeverityError
+	if options.AllowNonConventionalcommits {
+		parseSeverity = ruleSeverityWarn
 	}
-	machine := parser.NewMachine(machineOptions...)
 
-	failures := []int{}
-	for idx, commit := range commits {
-		ccMessage, err := machine.Parse([]byte(commit.Message))
-		if err != nil {
-			sLogger.Info(err.Error())
-			failures = append(failures, idx)
+	results := []commitLintResult{}
+	hasErrors, hasViolations := false, false
+	for _, commit := range commits {
+		if isRevertCommit(commit) {
+			sLogger.Infof("skipping commit %s, as it is a revert written by git", commit.Hash)
 			continue
 		}
-		if !ccMessage.Ok() {
-			failures = append(failures, idx)
+
+		result := lintCommit(machine, rules, commit.Hash, commit.fullMessage(), parseSeverity)
+		for _, violation := range result.Violations {
+			sLogger.Infof("commit %s broke rule %s: %s", commit.Hash, violation.Rule, violation.Message)
 		}
-	}
 
-	if len(failures) > 0 {
-		sb := strings.Builder{}
-		sb.WriteString("not all commits were found to adhere to conventional commit principles\n\n")
+		hasErrors = hasErrors || result.hasSeverity(ruleSeverityError)
+		hasViolations = hasViolations || len(result.Violations) > 0
+		results = append(results, result)
+	}
 
-		for _, idx := range failures {
-			commit := commits[idx]
-			sb.WriteString(fmt.Sprintf("Commit: %s was not conventional commit, instead found unparseable message: %s\n", commit.Hash, commit.Message))
+	if options.ReportFormat != "" || options.ReportFile != "" {
+		if err := writeCommitLintReport(results, options.ReportFormat, options.ReportFile, options.ChangelogFile); err != nil {
+			sLogger.Error("failed to write the commit report")
+			sLogger.Fatal(err.Error())
 		}
 
-		if options.AllowNonConventionalcommits {
-			sLogger.Warn(sb.String())
-		} else {
-			sLogger.Fatal(sb.String())
+		if hasErrors {
+			failed := 0
+			for _, result := range results {
+				if result.hasSeverity(ruleSeverityError) {
+					failed++
+				}
+			}
+			sLogger.Fatalf("%d of %d commits were found not to adhere to conventional commit principles", failed, len(results))
 		}
+		return
+	}
+
+	if hasErrors {
+		sLogger.Fatal(commitLintReport(results))
+	} else if hasViolations {
+		sLogger.Warn(commitLintReport(results))
 	}
 }
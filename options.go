@@ -7,7 +7,7 @@ import (
 	"github.com/jessevdk/go-flags"
 )
 
-func parseOptions(options interface{}, ignoreUnknown ...bool) {
+func parseOptions(options interface{}, ignoreUnknown ...bool) []string {
 	sLogger.Debug("loading cli options into interface")
 	sLogger.Debug(reflect.TypeOf(options).String())
 
@@ -18,7 +18,8 @@ func parseOptions(options interface{}, ignoreUnknown ...bool) {
 		parser = flags.NewParser(options, flags.None)
 	}
 
-	if _, err := parser.ParseArgs(os.Args); err != nil {
+	args, err := parser.ParseArgs(os.Args)
+	if err != nil {
 		if parseErr, ok := err.(*flags.Error); ok {
 			if parseErr.Type == flags.ErrHelp {
 				os.Exit(0)
@@ -27,12 +28,18 @@ func parseOptions(options interface{}, ignoreUnknown ...bool) {
 		sLogger.Fatal(err.Error())
 	}
 	sLogger.Debug("successfully loaded cli options")
+
+	return args
 }
 
 // GlobalOptions is the global options for all cli operations
 type GlobalOptions struct {
-	LogLevel      []bool `short:"l" long:"log-level" description:"Level of logging verbosity"`
-	ChangelogFile string `short:"f" long:"changelog-file" description:"Location of the changelog file" default:"./CHANGELOG.md"`
+	LogLevel      []bool   `short:"l" long:"log-level" description:"Level of logging verbosity"`
+	ChangelogFile string   `short:"f" long:"changelog-file" description:"Location of the changelog file" default:"./CHANGELOG.md"`
+	Sections      []string `long:"section" description:"A section supported in the changelog, in the form <type>[=<heading>], set in order by calling the flag multiple times"`
+	CommitTypes   []string `long:"commit-type" description:"A custom conventional commit type, in the form <type>[=<increment>], where increment is MAJOR, MINOR, or PATCH, eg. security=PATCH"`
+	TypeSections  []string `long:"type-section" description:"Record a conventional commit type under a section, in the form <type>=<section>, eg. perf=Changed"`
+	ExcludeTypes  []string `long:"exclude-type" description:"A conventional commit type to exclude from the changelog, eg. chore"`
 }
 
 // GeneralGitOptions are the options used most generally for git supporting operations
@@ -46,8 +53,12 @@ type GeneralGitOptions struct {
 type NewVersionOptions struct {
 	GlobalOptions
 	GeneralGitOptions
+	EntryStyleOptions
+	CommitFilterOptions
+	ReferenceLinkOptions
 	Increment                 string   `short:"i" long:"increment" description:"The incrementation level to use"`
 	Force                     bool     `short:"o" long:"force" description:"If there's a pending release in the changelog, should it be overwritten by this run?"`
+	Merge                     bool     `long:"merge" description:"If there's a pending release in the changelog, should the new entries be merged into it?"`
 	Manual                    bool     `short:"m" long:"manual" description:"Don't attempt to evaluate any changes from git, and only load manually"`
 	NonInteractive            bool     `short:"n" long:"non-interactive" description:"Should the step be run non interactively?"`
 	IgnoreConventionalCommits bool     `short:"g" long:"ignore-conventionalcommits" description:"Should conventional commits be ignored?"`
@@ -57,15 +68,48 @@ type NewVersionOptions struct {
 	Removed                   []string `short:"r" long:"removed" description:"What was removed in this new release?"`
 	Fixed                     []string `short:"x" long:"fixed" description:"What was fixed in this new release?"`
 	Security                  []string `short:"e" long:"security" description:"What was security related in this new release?"`
+	Entries                   []string `long:"entry" description:"An entry for any configured section in this new release, in the form <type>=<entry>"`
 	Depth                     int      `short:"d" long:"depth" description:"How deep to go when checking that all commits are conventional" default:"0"`
 	AuditClogFile             bool     `short:"u" long:"audit-changelog-file" description:"If there are changes to the changelog file, should these be included in the changelog?"`
 }
 
+// PrintUnreleasedVersionOptions are the options used by the print-unreleased-version operation
+type PrintUnreleasedVersionOptions struct {
+	GlobalOptions
+	GitLookupOptions
+	CommitFilterOptions
+	Depth int `short:"d" long:"depth" description:"How deep to go when checking that all commits are conventional" default:"0"`
+}
+
 type PrintChangesOptions struct {
 	GlobalOptions
 	Version string `short:"v" long:"version" description:"A version string to use to lookup changes"`
 }
 
+// EntryStyleOptions are the options for how entries resolved from conventional commits are written
+type EntryStyleOptions struct {
+	EntryStyle    string `long:"entry-style" description:"How entries are written from conventional commits, either files, for an entry per changed file, or commits, for an entry per commit" default:"files"`
+	NoCommitHash  bool   `long:"no-commit-hash" description:"Should the short hash of the commit be left out of commit style entries?"`
+	CommitFiles   bool   `long:"commit-files" description:"Should the changed files be listed under commit style entries?"`
+	CommitAuthors bool   `long:"commit-authors" description:"Should the author, and any co-authors, of the commits be credited in their entries?"`
+	Contributors  bool   `long:"contributors" description:"Should a list of the contributors, de-duplicated using the mailmap, be recorded under the release?"`
+}
+
+// CommitFilterOptions are the options to limit the conventional commits used to resolve a version
+type CommitFilterOptions struct {
+	Scopes        []string `long:"scope" description:"Only use conventional commits with this scope, set multiple times for multiple scopes"`
+	ExcludeScopes []string `long:"exclude-scope" description:"Ignore conventional commits with this scope, set multiple times for multiple scopes"`
+	Paths         []string `long:"path" description:"Only use commits touching this path, or glob, relative to the git working directory, set multiple times for multiple paths, defaults to the git working directory when it is a sub-directory of the repository"`
+}
+
+// ReferenceLinkOptions are the options for linking issue and pull request references in entries
+type ReferenceLinkOptions struct {
+	LinkReferences bool     `long:"link-references" description:"Should issue and pull request references in entries, eg. #123, GH-123, or a jira key, be turned into links?"`
+	IssueURL       string   `long:"issue-url" description:"URL template for #123 and GH-123 references, where {id} is the number, defaults to the issues URL of the git remote"`
+	JiraKeys       []string `long:"jira-key" description:"A jira project key to link references for, eg. ABC for ABC-123, set multiple times for multiple keys"`
+	JiraURL        string   `long:"jira-url" description:"URL template for jira references, where {id} is the issue key, eg. https://example.atlassian.net/browse/{id}"`
+}
+
 // GitLookupOptions are generral the options used operations running git lookup commands
 type GitLookupOptions struct {
 	GitEvaluate         bool   `short:"e" long:"git-evaluate" description:"Should git branches be evaluated when calcuating the most recent version?"`
@@ -78,9 +122,17 @@ type GitLookupOptions struct {
 type UpdateOptions struct {
 	GlobalOptions
 	GitLookupOptions
-	GitBranch     string `short:"b" long:"git-branch" description:"Git branch to run against"`
-	Depth         int    `short:"d" long:"depth" description:"How deep to go when checking that all commits are conventional" default:"0"`
-	AuditClogFile bool   `short:"u" long:"audit-changelog-file" description:"If there are changes to the changelog file, should these be included in the changelog?"`
+	EntryStyleOptions
+	CommitFilterOptions
+	ReferenceLinkOptions
+	GitBranch      string `short:"b" long:"git-branch" description:"Git branch to run against"`
+	Depth          int    `short:"d" long:"depth" description:"How deep to go when checking that all commits are conventional" default:"0"`
+	AuditClogFile  bool   `short:"u" long:"audit-changelog-file" description:"If there are changes to the changelog file, should these be included in the changelog?"`
+	Prerelease     string `long:"prerelease" description:"Pre-release identifier to release the version under, eg. rc"`
+	VersionPrefix  string `short:"v" long:"version-prefix" description:"Prefix for the version" default:"v"`
+	CompareLinks   bool   `long:"compare-links" description:"Should the compare link definitions at the bottom of the changelog be added/refreshed? On by default when the changelog already has them"`
+	NoCompareLinks bool   `long:"no-compare-links" description:"Should the compare link definitions be left as they are, even when the changelog already has them?"`
+	CompareURL     string `long:"compare-url" description:"Base URL of the repository for compare links, defaults to the URL of the git remote"`
 }
 
 // ReleaseOptions are the options used by the release operation
@@ -90,13 +142,73 @@ type ReleaseOptions struct {
 	NonInteractive   bool     `short:"n" long:"non-interactive" description:"Should the step be run non interactively?"`
 	GitCommitMessage string   `short:"m" long:"git-commit-message" description:"The message to use for the git commit" default:"[skip ci] Release version %s"`
 	ReleaseFiles     []string `short:"r" long:"release-file" description:"Additional files to add to the release"`
-	VersionPrefix    string   `short:"v" long:"version-prefix" description:"Prefix for the version" default:"v"`
+}
+
+// FormatOptions are the options used by the fmt operation
+type FormatOptions struct {
+	GlobalOptions
+	Check bool `short:"c" long:"check" description:"Only check the formatting, printing a diff and exiting non zero if the changelog is not formatted"`
+}
+
+// PromoteOptions are the options used by the promote operation
+type PromoteOptions struct {
+	ReleaseOptions
+	PromoteVersion string `long:"promote-version" description:"The version to promote the pre-releases of, defaults to the version of the latest pre-release"`
+	Collapse       bool   `long:"collapse" description:"Should the promoted pre-release sections be removed from the changelog?"`
+}
+
+// InitOptions are the options used when creating a new changelog file
+type InitOptions struct {
+	GlobalOptions
+	GitLookupOptions
+	EntryStyleOptions
+	VersionPrefix string `short:"v" long:"version-prefix" description:"Prefix for the version" default:"v"`
+	FromHistory   bool   `long:"from-history" description:"Should the changelog be built from the release tags/branches, and conventional commits in git?"`
+	Force         bool   `short:"o" long:"force" description:"If the changelog file already exists, should it be overwritten?"`
+}
+
+// ExportOptions are the options used when exporting the changelog to structured data
+type ExportOptions struct {
+	GlobalOptions
+	Format string `short:"t" long:"format" description:"The format to export the changelog as, either json or yaml" default:"json"`
+	Output string `short:"o" long:"output" description:"File to write the exported changelog to, by default it is printed"`
+}
+
+// YankOptions are the options used by the yank operation
+type YankOptions struct {
+	GlobalOptions
+	GitLookupOptions
+	VersionPrefix string `short:"v" long:"version-prefix" description:"Prefix for the version" default:"v"`
+	DeleteRef     bool   `long:"delete-ref" description:"Should the release branch/tag of the yanked version be deleted?"`
+}
+
+// CommitRuleOptions are the rules commit messages are checked against, on top of being conventional commits
+type CommitRuleOptions struct {
+	Rules []string `long:"rule" description:"A rule to check commits against, in the form <name>[:<severity>][=<value>], eg. header-max-length:warn=72, set multiple times for multiple rules"`
+}
+
+// LintMessageOptions are the options used by the lint-message operation
+type LintMessageOptions struct {
+	GlobalOptions
+	CommitRuleOptions
+}
+
+// InstallHooksOptions are the options used by the install-hooks operation
+type InstallHooksOptions struct {
+	GlobalOptions
+	CommitRuleOptions
+	GitWorkingDirectory string `short:"w" long:"git-workdir" description:"Working directory of the git repository" default:"./"`
+	Command             string `long:"command" description:"The command the hook runs changehelper with" default:"changehelper"`
+	Force               bool   `short:"o" long:"force" description:"If a commit-msg hook not installed by changehelper already exists, should it be overwritten?"`
 }
 
 // EnforceConventionalCommitsOptions sare the options used by the enforce conventional commits operation
 type EnforceConventionalCommitsOptions struct {
 	GlobalOptions
 	GeneralGitOptions
-	Depth                       int  `short:"d" long:"depth" description:"How deep to go when checking that all commits are conventional" default:"0"`
-	AllowNonConventionalcommits bool `short:"a" long:"allow" description:"Allows non conventional commits to be present. Will pass if at least one conventional commits is found"`
+	CommitRuleOptions
+	Depth                       int    `short:"d" long:"depth" description:"How deep to go when checking that all commits are conventional" default:"0"`
+	AllowNonConventionalcommits bool   `short:"a" long:"allow" description:"Allows non conventional commits to be present. Will pass if at least one conventional commits is found"`
+	ReportFormat                string `long:"report-format" description:"Format to write a report of the checked commits in, either junit, sarif, github, or json"`
+	ReportFile                  string `long:"report-file" description:"File to write the report to, by default it is printed"`
 }
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const referenceIDPlaceholder = "{id}"
+
+// jiraKeyRegex matches a valid jira project key, eg. ABC or AB_2
+var jiraKeyRegex = regexp.MustCompile(`^[A-Z][A-Z0-9_]*$`)
+
+// referenceLinker turns the issue and pull request references in entries into markdown links
+type referenceLinker struct {
+	IssueURL string
+	JiraURL  string
+	Regex    *regexp.Regexp
+}
+
+// newReferenceLinker builds the linker from the options, where without an issue url set, the issues
+// url of the git remote is used, eg. https://github.com/org/repo/issues/{id}
+func newReferenceLinker(options ReferenceLinkOptions, git gitCli) (*referenceLinker, error) {
+	linker := referenceLinker{
+		IssueURL: options.IssueURL,
+		JiraURL:  options.JiraURL,
+	}
+
+	if linker.IssueURL == "" {
+		remoteURL, err := git.getRemoteURL(getRemote(git))
+		if err != nil {
+			return nil, err
+		}
+		linker.IssueURL = remoteToWebURL(*remoteURL) + "/issues/" + referenceIDPlaceholder
+	}
+
+	jiraKeys := []string{}
+	for _, key := range options.JiraKeys {
+		key = strings.ToUpper(strings.TrimSpace(key))
+		if !jiraKeyRegex.MatchString(key) {
+			return nil, fmt.Errorf("jira key '%s' is not a valid project key", key)
+		}
+		jiraKeys = append(jiraKeys, key)
+	}
+	if len(jiraKeys) > 0 && linker.JiraURL == "" {
+		return nil, fmt.Errorf("jira keys %v are set without a jira url", jiraKeys)
+	}
+
+	// Existing links and inline code are matched first, so that references in them are left as they are,
+	// where links can be inline, eg. [#12](url), or reference links, eg. [#12][ref], [#12][] or [#12]
+	pattern := "\\[[^\\]]*\\](?:\\([^)]*\\)|\\[[^\\]]*\\])?|`[^`]*`|(?:#|\\bGH-)\\d+\\b"
+	if len(jiraKeys) > 0 {
+		pattern += fmt.Sprintf(`|\b(?:%s)-\d+\b`, strings.Join(jiraKeys, "|"))
+	}
+	linker.Regex = regexp.MustCompile(pattern)
+
+	return &linker, nil
+}
+
+func (l referenceLinker) referenceURL(reference string) string {
+	switch {
+	case strings.HasPrefix(reference, "#"):
+		return strings.ReplaceAll(l.IssueURL, referenceIDPlaceholder, strings.TrimPrefix(reference, "#"))
+	case strings.HasPrefix(reference, "GH-"):
+		return strings.ReplaceAll(l.IssueURL, referenceIDPlaceholder, strings.TrimPrefix(reference, "GH-"))
+	default:
+		return strings.ReplaceAll(l.JiraURL, referenceIDPlaceholder, reference)
+	}
+}
+
+// linkEntry replaces each reference in the entry with a link to it, skipping any # that follows a
+// word or a path, eg. page#12, so only standalone references are linked
+func (l referenceLinker) linkEntry(entry string) string {
+	sb := strings.Builder{}
+	pos := 0
+	for _, match := range l.Regex.FindAllStringIndex(entry, -1) {
+		reference := entry[match[0]:match[1]]
+		if strings.HasPrefix(reference, "[") || strings.HasPrefix(reference, "`") {
+			continue
+		}
+		if strings.HasPrefix(reference, "#") && match[0] > 0 {
+			if previous := entry[match[0]-1]; previous == '/' || previous == '&' || previous == '_' ||
+				(previous >= '0' && previous <= '9') || (previous >= 'a' && previous <= 'z') || (previous >= 'A' && previous <= 'Z') {
+				continue
+			}
+		}
+
+		sb.WriteString(entry[pos:match[0]])
+		sb.WriteString(fmt.Sprintf("[%s](%s)", reference, l.referenceURL(reference)))
+		pos = match[1]
+	}
+	sb.WriteString(entry[pos:])
+
+	return sb.String()
+}
+
+// linkReferences links the references in every entry of the change
+func (l referenceLinker) linkReferences(change *change) {
+	for _, section := range changeSections {
+		entries := change.sectionEntries(section.Type)
+		for idx, entry := range *entries {
+			(*entries)[idx] = l.linkEntry(entry)
+		}
+	}
+}
+
+// mustLinkReferences links the references in the entries of the change, if enabled by the options
+func mustLinkReferences(options ReferenceLinkOptions, change *change, git gitCli) {
+	if !options.LinkReferences {
+		return
+	}
+
+	linker, err := newReferenceLinker(options, git)
+	if err != nil {
+		sLogger.Error("failed to setup linking of issue and pull request references")
+		sLogger.Fatal(err.Error())
+	}
+
+	linker.linkReferences(change)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"strings"
+
 	"github.com/blang/semver"
 )
 
@@ -13,11 +15,33 @@ const (
 	MAJOR = "MAJOR"
 )
 
+// higherIncrement returns the highest of the increment levels, ignoring any that are unset
+func higherIncrement(increments ...string) string {
+	higher := ""
+	for _, increment := range increments {
+		switch increment {
+		case MAJOR:
+			return MAJOR
+		case MINOR:
+			higher = MINOR
+		case PATCH:
+			if higher == "" {
+				higher = PATCH
+			}
+		}
+	}
+
+	return higher
+}
+
 func getLatestRelease(released []*change) *change {
 	changeMap := map[string]*change{}
 	releasedVersions := make([]semver.Version, 0)
 
 	for _, change := range released {
+		if change.Yanked {
+			continue
+		}
 		changeMap[change.Version.String()] = change
 		releasedVersions = append(releasedVersions, *change.Version)
 	}
@@ -36,16 +60,15 @@ func getLatestRelease(released []*change) *change {
 }
 
 func listReleasedVersionFromGit(useTags bool, git gitCli, prefix string, remotes ...string) ([]semver.Version, error) {
-	releaseRefs, err := git.listRemoteBranches(prefix, remotes...)
-	if err != nil {
-		return nil, err
-	}
-
+	var releaseRefs []string
+	var err error
 	if useTags {
 		releaseRefs, err = git.listTags(prefix)
-		if err != nil {
-			return nil, err
-		}
+	} else {
+		releaseRefs, err = git.listRemoteBranches(prefix, remotes...)
+	}
+	if err != nil {
+		return nil, err
 	}
 
 	releasedVersions := make([]semver.Version, 0)
@@ -66,16 +89,102 @@ func listReleasedVersionFromGit(useTags bool, git gitCli, prefix string, remotes
 
 func updateUnreleasedVersion(unreleased *change, increment *string) {
 	if increment != nil {
+		// A pre-release precedes its own core version, so an increment that
+		// the core already satisfies only needs to drop the pre-release
+		isPrerelease := len(unreleased.Version.Pre) > 0
+
 		switch *increment {
 		case PATCH:
-			unreleased.Version.Patch++
+			if !isPrerelease {
+				unreleased.Version.Patch++
+			}
 		case MINOR:
-			unreleased.Version.Minor++
+			if !isPrerelease || unreleased.Version.Patch != 0 {
+				unreleased.Version.Minor++
+			}
 			unreleased.Version.Patch = 0
 		case MAJOR:
-			unreleased.Version.Major++
+			if !isPrerelease || unreleased.Version.Minor != 0 || unreleased.Version.Patch != 0 {
+				unreleased.Version.Major++
+			}
 			unreleased.Version.Minor = 0
 			unreleased.Version.Patch = 0
 		}
+
+		unreleased.Version.Pre = nil
+		unreleased.Version.Build = nil
 	}
 }
+
+func setPrereleaseVersion(version *semver.Version, prerelease string, released []*change) error {
+	identifiers := []semver.PRVersion{}
+	for _, part := range strings.Split(prerelease, ".") {
+		identifier, err := semver.NewPRVersion(part)
+		if err != nil {
+			return err
+		}
+		identifiers = append(identifiers, identifier)
+	}
+
+	counter := uint64(0)
+	for _, release := range released {
+		if release.Version == nil || len(release.Version.Pre) != len(identifiers)+1 {
+			continue
+		}
+		if release.Version.Major != version.Major || release.Version.Minor != version.Minor || release.Version.Patch != version.Patch {
+			continue
+		}
+
+		matchesIdentifiers := true
+		for idx, identifier := range identifiers {
+			if release.Version.Pre[idx].Compare(identifier) != 0 {
+				matchesIdentifiers = false
+				break
+			}
+		}
+
+		releaseCounter := release.Version.Pre[len(identifiers)]
+		if matchesIdentifiers && releaseCounter.IsNumeric() && releaseCounter.VersionNum > counter {
+			counter = releaseCounter.VersionNum
+		}
+	}
+
+	sLogger.Debugf("found pre-release counter %d for %s-%s", counter, version.String(), prerelease)
+
+	version.Pre = append(identifiers, semver.PRVersion{VersionNum: counter + 1, IsNum: true})
+	version.Build = nil
+
+	return nil
+}
+
+func prereleaseText(version *semver.Version) string {
+	identifiers := []string{}
+	for _, identifier := range version.Pre {
+		identifiers = append(identifiers, identifier.String())
+	}
+
+	return strings.Join(identifiers, ".")
+}
+
+// skipYankedVersions increments the unreleased version again while it is a yanked release, as a
+// yanked version is still taken, eg. with 1.3.0 yanked, a minor increment from 1.2.0 is 1.4.0
+func skipYankedVersions(unreleased *change, increment *string, released []*change) {
+	if increment == nil {
+		return
+	}
+
+	for isYanked(*unreleased.Version, released) {
+		sLogger.Debugf("version %s is yanked, incrementing past it", unreleased.Version.String())
+		updateUnreleasedVersion(unreleased, increment)
+	}
+}
+
+func isYanked(version semver.Version, released []*change) bool {
+	for _, release := range released {
+		if release.Yanked && release.Version.Equals(version) {
+			return true
+		}
+	}
+
+	return false
+}
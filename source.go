@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bytes"
+	"sort"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+// changelogEdit replaces the bytes between Start and End of the changelog with Text
+type changelogEdit struct {
+	Start  int
+	End    int
+	Text   string
+	Insert bool
+}
+
+func applyChangelogEdits(changelog []byte, edits []changelogEdit) []byte {
+	sort.SliceStable(edits, func(i, j int) bool {
+		if edits[i].Start == edits[j].Start {
+			return edits[i].End < edits[j].End
+		}
+		return edits[i].Start < edits[j].Start
+	})
+
+	sb := strings.Builder{}
+	pos := 0
+	for _, edit := range edits {
+		sb.Write(changelog[pos:edit.Start])
+		pos = edit.End
+
+		if edit.Insert {
+			before := sb.String()
+			if before != "" && !strings.HasSuffix(before, "\n\n") {
+				if !strings.HasSuffix(before, "\n") {
+					sb.WriteString("\n")
+				}
+				sb.WriteString("\n")
+			}
+
+			sb.WriteString(edit.Text)
+
+			if pos < len(changelog) && changelog[pos] != '\n' {
+				sb.WriteString("\n")
+			}
+			continue
+		}
+
+		sb.WriteString(edit.Text)
+	}
+	sb.Write(changelog[pos:])
+
+	return []byte(sb.String())
+}
+
+// nodeText reads the raw source of a block, as the rendered text of headings like
+// [1.0.0] loses the brackets when a matching link reference definition exists
+func nodeText(node ast.Node, source []byte) string {
+	if node.Type() == ast.TypeBlock && node.Lines().Len() > 0 {
+		sb := strings.Builder{}
+		lines := node.Lines()
+		for idx := 0; idx < lines.Len(); idx++ {
+			line := lines.At(idx)
+			sb.Write(line.Value(source))
+		}
+
+		return strings.TrimSpace(sb.String())
+	}
+
+	return string(node.Text(source))
+}
+
+func nodeStart(node ast.Node, source []byte) int {
+	if node.Type() != ast.TypeBlock {
+		return -1
+	}
+
+	if codeBlock, ok := node.(*ast.FencedCodeBlock); ok {
+		// The opening fence isn't part of the block lines, so take the line preceding the code
+		if codeBlock.Info != nil {
+			return codeBlock.Info.Segment.Start
+		}
+		if lines := node.Lines(); lines.Len() > 0 && lines.At(0).Start > 0 {
+			return lineStart(source, lines.At(0).Start-1)
+		}
+	}
+
+	if lines := node.Lines(); lines.Len() > 0 {
+		return lines.At(0).Start
+	}
+
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if start := nodeStart(child, source); start >= 0 {
+			return start
+		}
+	}
+
+	return -1
+}
+
+func nodeStop(node ast.Node, source []byte) int {
+	stop := -1
+	if node.Type() != ast.TypeBlock {
+		return stop
+	}
+
+	if lines := node.Lines(); lines.Len() > 0 {
+		stop = lineEnd(source, lines.At(lines.Len()-1).Stop)
+	}
+
+	for child := node.FirstChild(); child != nil; child = child.NextSibling() {
+		if childStop := nodeStop(child, source); childStop > stop {
+			stop = childStop
+		}
+	}
+
+	switch block := node.(type) {
+	case *ast.FencedCodeBlock:
+		// The closing fence isn't part of the block lines, so take the line following the code
+		if stop >= 0 && stop < len(source) {
+			closingFence := strings.TrimSpace(string(source[stop:lineEnd(source, stop+1)]))
+			if strings.HasPrefix(closingFence, "```") || strings.HasPrefix(closingFence, "~~~") {
+				stop = lineEnd(source, stop+1)
+			}
+		}
+	case *ast.HTMLBlock:
+		if block.HasClosure() && block.ClosureLine.Stop > stop {
+			stop = lineEnd(source, block.ClosureLine.Stop)
+		}
+	}
+
+	return stop
+}
+
+// contentEnd finds the end of the last markdown block in the changelog, ignoring
+// any trailing link reference definitions
+func contentEnd(changelog []byte) int {
+	document := goldmark.DefaultParser().Parse(text.NewReader(changelog))
+
+	end := 0
+	for node := document.FirstChild(); node != nil; node = node.NextSibling() {
+		if stop := nodeStop(node, changelog); stop > end {
+			end = stop
+		}
+	}
+
+	return end
+}
+
+func lineStart(source []byte, pos int) int {
+	if pos <= 0 {
+		return 0
+	}
+
+	return bytes.LastIndexByte(source[:pos], '\n') + 1
+}
+
+func lineEnd(source []byte, pos int) int {
+	if pos >= len(source) {
+		return len(source)
+	}
+	if pos <= 0 {
+		pos = 0
+	} else if source[pos-1] == '\n' {
+		return pos
+	}
+
+	idx := bytes.IndexByte(source[pos:], '\n')
+	if idx < 0 {
+		return len(source)
+	}
+
+	return pos + idx + 1
+}
+
+func lineStartAfterBlankLines(source []byte, pos int) int {
+	for pos < len(source) {
+		next := lineEnd(source, pos+1)
+		if strings.TrimSpace(string(source[pos:next])) != "" {
+			break
+		}
+		pos = next
+	}
+
+	return pos
+}
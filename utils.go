@@ -157,15 +157,16 @@ func getUnreleased(changelogFile string) (*string, *semver.Version, error) {
 		return nil, nil, errors.New("the unreleased change has no increment set, so version cannot be determined")
 	}
 
-	if len(released) > 0 {
-		latest := getLatestRelease(released)
-		unreleased.Version = latest.Version
+	if latest := getLatestRelease(released); latest != nil {
+		latestVersion := *latest.Version
+		unreleased.Version = &latestVersion
 	} else {
 		defaultVersion := semver.MustParse("0.0.0")
 		unreleased.Version = &defaultVersion
 	}
 
 	updateUnreleasedVersion(unreleased, increment)
+	skipYankedVersions(unreleased, increment, released)
 
 	unreleased.renderChangeText(*increment)
 